@@ -0,0 +1,128 @@
+package jsonstruct
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// Base64 strings must be at least minBase64Length long. Strings without digits
+// or symbols must be at least minLetterBase64Length long. Shorter strings are
+// too likely to be words or identifiers.
+const (
+	minBase64Length       = 16
+	minLetterBase64Length = 24
+)
+
+// A base64Encoding is a base64 encoding.
+type base64Encoding struct {
+	name        string
+	encoding    *base64.Encoding
+	typeName    string
+	description string
+}
+
+// base64Encodings are the recognized base64 encodings, in order of precedence.
+// Standard base64 is unmarshalled natively into a []byte by encoding/json and
+// so does not need a helper type.
+var base64Encodings = []base64Encoding{
+	{
+		name:     "StdEncoding",
+		encoding: base64.StdEncoding,
+	},
+	{
+		name:        "URLEncoding",
+		encoding:    base64.URLEncoding,
+		typeName:    "URLBase64",
+		description: "URL-safe base64",
+	},
+	{
+		name:        "RawStdEncoding",
+		encoding:    base64.RawStdEncoding,
+		typeName:    "RawStdBase64",
+		description: "unpadded standard base64",
+	},
+	{
+		name:        "RawURLEncoding",
+		encoding:    base64.RawURLEncoding,
+		typeName:    "RawURLBase64",
+		description: "unpadded URL-safe base64",
+	},
+}
+
+const base64HelperDeclFormat = `// A %[1]s is a []byte that is marshalled as %[2]s.
+type %[1]s []byte
+
+// MarshalText implements encoding.TextMarshaler.
+func (b %[1]s) MarshalText() ([]byte, error) {
+	return []byte(base64.%[3]s.EncodeToString(b)), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (b *%[1]s) UnmarshalText(text []byte) error {
+	data, err := base64.%[3]s.DecodeString(string(text))
+	if err != nil {
+		return err
+	}
+	*b = data
+	return nil
+}`
+
+// isBase64Like returns true if s looks like base64-encoded binary data rather
+// than text. Such strings are long and contain both upper and lower case
+// letters, which also excludes hexadecimal strings.
+func isBase64Like(s string) bool {
+	switch {
+	case len(s) < minBase64Length:
+		return false
+	case !strings.ContainsFunc(s, unicode.IsUpper) || !strings.ContainsFunc(s, unicode.IsLower):
+		return false
+	case strings.ContainsAny(s, "0123456789+/-_="):
+		return true
+	default:
+		return len(s) >= minLetterBase64Length
+	}
+}
+
+// observeBase64 records which base64 encodings s is valid in. Only standard
+// base64 is considered unless options.detectBase64Variants is set.
+func (v *value) observeBase64(s string, options *observeOptions) {
+	if !options.detectBase64 || !isBase64Like(s) {
+		return
+	}
+	for _, base64Encoding := range base64Encodings {
+		if base64Encoding.typeName != "" && !options.detectBase64Variants {
+			continue
+		}
+		if _, err := base64Encoding.encoding.DecodeString(s); err == nil {
+			if v.base64Encodings == nil {
+				v.base64Encodings = make(map[string]int)
+			}
+			v.base64Encodings[base64Encoding.name]++
+		}
+	}
+}
+
+// base64TypeStr returns the Go type of the string v if all observed strings
+// are valid in the same base64 encoding, and declares any helper type.
+// Otherwise it returns the empty string.
+func (v *value) base64TypeStr(options *generateOptions) string {
+	if v.strings == 0 {
+		return ""
+	}
+	for _, base64Encoding := range base64Encodings {
+		if v.base64Encodings[base64Encoding.name] != v.strings {
+			continue
+		}
+		if base64Encoding.typeName == "" {
+			return "[]byte"
+		}
+		options.imports["encoding/base64"] = struct{}{}
+		options.declareHelper(base64Encoding.typeName, fmt.Sprintf(base64HelperDeclFormat,
+			base64Encoding.typeName, base64Encoding.description, base64Encoding.name,
+		))
+		return base64Encoding.typeName
+	}
+	return ""
+}
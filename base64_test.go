@@ -0,0 +1,26 @@
+package jsonstruct
+
+import (
+	"testing"
+
+	"github.com/alecthomas/assert/v2"
+)
+
+func TestIsBase64Like(t *testing.T) {
+	for _, tc := range []struct {
+		s        string
+		expected bool
+	}{
+		{s: "", expected: false},
+		{s: "aGVsbG8=", expected: false},
+		{s: "HelloWorldFooBar", expected: false},
+		{s: "0123456789abcdef0123456789abcdef", expected: false},
+		{s: "V2oH6SbDKflMmn6UjW4Pe9Vn", expected: true},
+		{s: "VzvLHzHv9JL_dvAFw0xPYg", expected: true},
+		{s: "QUJDREVGR0hJSktMTU5PUFFSU1RVVldY", expected: true},
+	} {
+		t.Run(tc.s, func(t *testing.T) {
+			assert.Equal(t, tc.expected, isBase64Like(tc.s))
+		})
+	}
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"compress/gzip"
+	"errors"
 	"fmt"
 	"io"
 	"os"
@@ -15,23 +16,84 @@ import (
 var (
 	abbreviations            = pflag.String("abbreviations", "", "comma-separated list of extra abbreviations")
 	format                   = pflag.String("format", "json", "format (json or yaml)")
+	decimalImport            = pflag.String("decimal-import", "", "import path of the decimal type")
+	decimalNames             = pflag.StringSlice("decimal-names", nil, "comma-separated list of property name suffixes that indicate decimals")
+	decimalType              = pflag.String("decimal-type", "", "decimal type")
+	deduplicateTypes         = pflag.String("deduplicate-types", "never", "share named types between nested objects (never, identical, or compatible)")
+	detectBase64             = pflag.Bool("detect-base64", false, "generate []byte for base64 strings")
+	detectBase64Variants     = pflag.Bool("detect-base64-variants", false, "generate helper types for URL-safe and unpadded base64 strings")
+	detectDecimals           = pflag.Bool("detect-decimals", false, "generate decimal types for prices and other fixed-precision numbers")
+	detectDurations          = pflag.Bool("detect-durations", false, "generate duration types for strings like 30s or 1h30m")
+	detectEpochTimes         = pflag.Bool("detect-epoch-times", false, "generate time types for integer properties that contain times since the Unix epoch")
+	detectISO8601Durations   = pflag.Bool("detect-iso8601-durations", false, "generate duration types for ISO 8601 durations like PT5M")
+	detectMaps               = pflag.Bool("detect-maps", false, "generate objects with dynamic keys as maps")
+	detectRecursiveTypes     = pflag.Bool("detect-recursive-types", false, "generate self-referential types for nested objects that repeat their ancestors")
+	detectStringFormats      = pflag.Bool("detect-string-formats", false, "detect UUIDs, URLs, IP addresses, CIDR prefixes, MAC addresses, email addresses, and hostnames")
+	detectTuples             = pflag.Bool("detect-tuples", false, "generate fixed-length arrays and tuple structs for arrays that always have the same length")
+	detectUnions             = pflag.Bool("detect-unions", false, "generate discriminated unions for objects whose properties depend on a string property")
+	discriminators           = pflag.StringSlice("discriminators", nil, "comma-separated list of discriminator property names")
 	decompress               = pflag.BoolP("gzip", "z", false, "decompress input with gzip")
+	epochTimeSuffixes        = pflag.StringSlice("epoch-time-suffixes", nil, "comma-separated list of property name suffixes that indicate epoch times")
+	enumValidation           = pflag.Bool("enum-validation", false, "generate validation methods for enum types")
+	extractNestedTypes       = pflag.Bool("extract-nested-types", false, "generate nested objects as separate named types")
 	fileHeader               = pflag.String("file-header", "", "file header")
 	ignoreErrors             = pflag.Bool("ignore-errors", false, "ignore errors")
+	mapPaths                 = pflag.StringSlice("map-paths", nil, "comma-separated list of paths of objects to generate as maps")
+	mapThreshold             = pflag.Int("map-threshold", 8, "minimum number of distinct keys for an object to be detected as a map")
+	nullableTypes            = pflag.String("nullable-types", "pointers", "type of nullable values (pointers or sql)")
+	numbers                  = pflag.String("numbers", "observed", "normalize numbers (observed, integral-floats-as-ints, floats-as-float64, or all-float64)")
+	numbersOverrides         = pflag.StringToString("numbers-overrides", nil, "comma-separated list of path=numbers pairs overriding --numbers")
 	omitEmptyTags            = pflag.String("omitempty-tags", "auto", "generate ,omitempty tags (never, always, or auto)")
 	omitZeroTags             = pflag.String("omitzero-tags", "auto", "generate ,omitzero tags (never, always, or auto)")
+	optionalTypes            = pflag.Bool("optional-types", false, "generate Optional[T] for properties that are absent, null, or values")
 	packageComment           = pflag.String("package-comment", "", "package comment")
 	packageName              = pflag.String("package-name", "main", "package name")
+	pointers                 = pflag.String("pointers", "auto", "use pointers for values that may be absent or null (auto, absent-or-null, null-only, or never)")
+	polymorphicTypes         = pflag.Bool("polymorphic-types", false, "generate helper types for string-or-number and value-or-array properties")
 	skipUnparsableProperties = pflag.Bool("skip-unparsable-properties", true, "skip unparsable properties")
+	stringEnums              = pflag.Int("string-enums", 0, "maximum number of distinct values of a string enum, or zero to disable")
+	structPaths              = pflag.StringSlice("struct-paths", nil, "comma-separated list of paths of objects to generate as structs")
 	stringTags               = pflag.Bool("string-tags", false, "generate ,string tags")
 	structTagName            = pflag.String("struct-tag-name", "", "struct tag name")
+	timeLayouts              = pflag.StringArray("time-layout", nil, "additional time layout, may be repeated")
+	typedMapKeys             = pflag.Bool("typed-map-keys", false, "generate integer, time, or UUID map keys where possible")
 	typeComment              = pflag.String("type-comment", "", "type comment")
 	typeName                 = pflag.String("type-name", "T", "type name")
+	intEnums                 = pflag.Int("int-enums", 0, "maximum number of distinct values of an integer enum, or zero to disable")
 	intType                  = pflag.String("int-type", "", "integer type")
+	intTypes                 = pflag.String("int-types", "fixed", "choose integer types from observed values (fixed, exact, signed, or widen)")
+	largeInts                = pflag.String("large-ints", "float64", "type of integers that do not fit in an int64 (float64, json-number, or big-int)")
+	unparsableProperties     = pflag.String("unparsable-properties", "comment", "handle properties that cannot be struct tag names (comment or methods)")
 	useJSONNumber            = pflag.Bool("use-json-number", false, "use json.Number")
 	goFormat                 = pflag.Bool("go-format", true, "format generated Go code")
 	output                   = pflag.StringP("output", "o", "", "output filename")
 
+	deduplicateTypesType = map[string]jsonstruct.DeduplicateTypesType{
+		"never":      jsonstruct.DeduplicateTypesNever,
+		"identical":  jsonstruct.DeduplicateTypesIdentical,
+		"compatible": jsonstruct.DeduplicateTypesCompatible,
+	}
+	intTypesType = map[string]jsonstruct.IntTypesType{
+		"fixed":  jsonstruct.IntTypesFixed,
+		"exact":  jsonstruct.IntTypesExact,
+		"signed": jsonstruct.IntTypesSigned,
+		"widen":  jsonstruct.IntTypesWiden,
+	}
+	largeIntsType = map[string]jsonstruct.LargeIntsType{
+		"float64":     jsonstruct.LargeIntsFloat64,
+		"json-number": jsonstruct.LargeIntsJSONNumber,
+		"big-int":     jsonstruct.LargeIntsBigInt,
+	}
+	nullableTypesType = map[string]jsonstruct.NullableTypesType{
+		"pointers": jsonstruct.NullableTypesPointers,
+		"sql":      jsonstruct.NullableTypesSQL,
+	}
+	numbersType = map[string]jsonstruct.NumbersType{
+		"observed":                jsonstruct.NumbersAsObserved,
+		"integral-floats-as-ints": jsonstruct.NumbersIntegralFloatsAsInts,
+		"floats-as-float64":       jsonstruct.NumbersFloatsAsFloat64,
+		"all-float64":             jsonstruct.NumbersAllFloat64,
+	}
 	omitEmptyTagsType = map[string]jsonstruct.OmitEmptyTagsType{
 		"never":  jsonstruct.OmitEmptyTagsNever,
 		"always": jsonstruct.OmitEmptyTagsAlways,
@@ -42,17 +104,54 @@ var (
 		"always": jsonstruct.OmitZeroTagsAlways,
 		"auto":   jsonstruct.OmitZeroTagsAuto,
 	}
+	pointersType = map[string]jsonstruct.PointersType{
+		"auto":           jsonstruct.PointersAuto,
+		"absent-or-null": jsonstruct.PointersAbsentOrNull,
+		"null-only":      jsonstruct.PointersNullOnly,
+		"never":          jsonstruct.PointersNever,
+	}
+	unparsablePropertiesType = map[string]jsonstruct.UnparsablePropertiesType{
+		"comment": jsonstruct.UnparsablePropertiesComment,
+		"methods": jsonstruct.UnparsablePropertiesMethods,
+	}
 )
 
 func run() error {
 	pflag.Parse()
 
 	options := []jsonstruct.GeneratorOption{
+		jsonstruct.WithDeduplicateTypes(deduplicateTypesType[*deduplicateTypes]),
+		jsonstruct.WithDetectBase64(*detectBase64 || *detectBase64Variants),
+		jsonstruct.WithDetectBase64Variants(*detectBase64Variants),
+		jsonstruct.WithDetectDecimals(*detectDecimals),
+		jsonstruct.WithDetectDurations(*detectDurations),
+		jsonstruct.WithDetectEpochTimes(*detectEpochTimes),
+		jsonstruct.WithDetectISO8601Durations(*detectISO8601Durations),
+		jsonstruct.WithDetectMaps(*detectMaps),
+		jsonstruct.WithDetectRecursiveTypes(*detectRecursiveTypes),
+		jsonstruct.WithDetectTuples(*detectTuples),
+		jsonstruct.WithDetectUnions(*detectUnions),
+		jsonstruct.WithDiscriminators(*discriminators...),
+		jsonstruct.WithEnumValidation(*enumValidation),
+		jsonstruct.WithExtractNestedTypes(*extractNestedTypes),
 		jsonstruct.WithFileHeader(*fileHeader),
+		jsonstruct.WithIntEnums(*intEnums),
+		jsonstruct.WithIntTypes(intTypesType[*intTypes]),
+		jsonstruct.WithLargeInts(largeIntsType[*largeInts]),
+		jsonstruct.WithMapThreshold(*mapThreshold),
+		jsonstruct.WithNullableTypes(nullableTypesType[*nullableTypes]),
+		jsonstruct.WithNumbers(numbersType[*numbers]),
 		jsonstruct.WithOmitEmptyTags(omitEmptyTagsType[*omitEmptyTags]),
 		jsonstruct.WithOmitZeroTags(omitZeroTagsType[*omitZeroTags]),
+		jsonstruct.WithOptionalTypes(*optionalTypes),
+		jsonstruct.WithPointers(pointersType[*pointers]),
+		jsonstruct.WithPolymorphicTypes(*polymorphicTypes),
 		jsonstruct.WithSkipUnparsableProperties(*skipUnparsableProperties),
+		jsonstruct.WithStringEnums(*stringEnums),
 		jsonstruct.WithStringTags(*stringTags),
+		jsonstruct.WithTimeLayouts(*timeLayouts...),
+		jsonstruct.WithTypedMapKeys(*typedMapKeys),
+		jsonstruct.WithUnparsableProperties(unparsablePropertiesType[*unparsableProperties]),
 		jsonstruct.WithUseJSONNumber(*useJSONNumber),
 		jsonstruct.WithGoFormat(*goFormat),
 	}
@@ -60,6 +159,42 @@ func run() error {
 		extraAbbreviations := strings.Split(strings.ToUpper(*abbreviations), ",")
 		options = append(options, jsonstruct.WithExtraAbbreviations(extraAbbreviations...))
 	}
+	if len(*mapPaths) > 0 || len(*structPaths) > 0 {
+		mapOverrides := make(map[string]bool)
+		for _, mapPath := range *mapPaths {
+			mapOverrides[mapPath] = true
+		}
+		for _, structPath := range *structPaths {
+			mapOverrides[structPath] = false
+		}
+		options = append(options, jsonstruct.WithMapOverrides(mapOverrides))
+	}
+	if len(*decimalNames) > 0 {
+		options = append(options, jsonstruct.WithDecimalNames(*decimalNames...))
+	}
+	switch {
+	case *decimalType != "":
+		options = append(options, jsonstruct.WithDecimalType(*decimalType, *decimalImport))
+	case *decimalImport != "":
+		return errors.New("--decimal-import requires --decimal-type")
+	}
+	if len(*epochTimeSuffixes) > 0 {
+		options = append(options, jsonstruct.WithEpochTimeSuffixes(*epochTimeSuffixes...))
+	}
+	if *detectStringFormats {
+		options = append(options, jsonstruct.WithStringFormats(jsonstruct.DefaultStringFormats()...))
+	}
+	if len(*numbersOverrides) > 0 {
+		overrides := make(map[string]jsonstruct.NumbersType)
+		for path, numbers := range *numbersOverrides {
+			numbersType, ok := numbersType[numbers]
+			if !ok {
+				return fmt.Errorf("%s: unknown numbers: %s", path, numbers)
+			}
+			overrides[path] = numbersType
+		}
+		options = append(options, jsonstruct.WithNumbersOverrides(overrides))
+	}
 	if *intType != "" {
 		options = append(options, jsonstruct.WithIntType(*intType))
 	}
@@ -0,0 +1,69 @@
+package jsonstruct
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Fixed-scale numbers have between minDecimalScale and maxDecimalScale
+// fractional digits.
+const (
+	minDecimalScale = 2
+	maxDecimalScale = 4
+)
+
+// fractionDigits returns the number of fractional digits in the JSON number s,
+// including trailing zeros.
+func fractionDigits(s string) int {
+	mantissa, exponent, _ := strings.Cut(strings.ToLower(s), "e")
+	_, fraction, _ := strings.Cut(mantissa, ".")
+	digits := len(fraction)
+	if exponent != "" {
+		if e, err := strconv.Atoi(exponent); err == nil {
+			digits -= e
+		}
+	}
+	return max(digits, 0)
+}
+
+// observeFractionDigits records the number of fractional digits in the
+// non-integer JSON number s.
+func (v *value) observeFractionDigits(s string, options *observeOptions) {
+	if !options.trackFractionDigits {
+		return
+	}
+	digits := fractionDigits(s)
+	if digits == 0 {
+		return
+	}
+	if v.minFractionDigits == 0 || digits < v.minFractionDigits {
+		v.minFractionDigits = digits
+	}
+	if digits > v.maxFractionDigits {
+		v.maxFractionDigits = digits
+	}
+}
+
+// isDecimal returns true if the number v, which is located at path, should be
+// generated as a decimal. Numbers are decimals if their property name matches
+// options.decimalNames or if all their non-integer values have the same
+// number of fractional digits, between minDecimalScale and maxDecimalScale.
+func (v *value) isDecimal(path valuePath, options *generateOptions) bool {
+	if !options.detectDecimals || v.float64s == 0 {
+		return false
+	}
+	if i := path.propertyIndex(); i > 0 && hasSuffixComponents(path[i], options.decimalNames) {
+		return true
+	}
+	return v.float64s >= 2 &&
+		v.minFractionDigits == v.maxFractionDigits &&
+		minDecimalScale <= v.minFractionDigits && v.maxFractionDigits <= maxDecimalScale
+}
+
+// decimalTypeStr returns the Go type of decimals and adds its import.
+func (options *generateOptions) decimalTypeStr() string {
+	if options.decimalImport != "" {
+		options.imports[options.decimalImport] = struct{}{}
+	}
+	return options.decimalType
+}
@@ -0,0 +1,45 @@
+package jsonstruct
+
+import (
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/alecthomas/assert/v2"
+)
+
+func TestFractionDigits(t *testing.T) {
+	for _, tc := range []struct {
+		s        string
+		expected int
+	}{
+		{s: "1", expected: 0},
+		{s: "1.5", expected: 1},
+		{s: "19.99", expected: 2},
+		{s: "2.50", expected: 2},
+		{s: "1.25e1", expected: 1},
+		{s: "1.5E3", expected: 0},
+		{s: "1e-3", expected: 3},
+	} {
+		t.Run(tc.s, func(t *testing.T) {
+			assert.Equal(t, tc.expected, fractionDigits(tc.s))
+		})
+	}
+}
+
+func TestDecimalNames(t *testing.T) {
+	names := []string{"amount", "balance", "cost", "fee", "price", "total"}
+	expected := map[string]bool{
+		"fees":         false,
+		"id":           false,
+		"price":        true,
+		"priceList":    false,
+		"total_amount": true,
+		"unitPrice":    true,
+	}
+	for _, property := range slices.Sorted(maps.Keys(expected)) {
+		t.Run(property, func(t *testing.T) {
+			assert.Equal(t, expected[property], hasSuffixComponents(property, names))
+		})
+	}
+}
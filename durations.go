@@ -0,0 +1,144 @@
+package jsonstruct
+
+import (
+	"regexp"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// iso8601DurationRegexp matches ISO 8601 durations with days, hours, minutes,
+// and seconds. Years and months are not supported because they do not have a
+// fixed length.
+var iso8601DurationRegexp = regexp.MustCompile(`\A-?P(?:\d+D)?(?:T(?:\d+H)?(?:\d+M)?(?:\d+(?:\.\d+)?S)?)?\z`)
+
+const durationHelperDecl = `// A Duration is a time.Duration that is marshalled as a string like "1h30m".
+type Duration struct {
+	time.Duration
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (d Duration) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (d *Duration) UnmarshalText(text []byte) error {
+	duration, err := time.ParseDuration(string(text))
+	if err != nil {
+		return err
+	}
+	d.Duration = duration
+	return nil
+}`
+
+const iso8601DurationHelperDecl = `// An ISO8601Duration is a time.Duration that is marshalled as an ISO 8601
+// duration like "PT1H30M". Years and months are not supported.
+type ISO8601Duration struct {
+	time.Duration
+}
+
+// String returns d as an ISO 8601 duration.
+func (d ISO8601Duration) String() string {
+	duration := d.Duration
+	if duration == 0 {
+		return "PT0S"
+	}
+	b := &strings.Builder{}
+	if duration < 0 {
+		b.WriteByte('-')
+		duration = -duration
+	}
+	b.WriteString("PT")
+	if hours := duration / time.Hour; hours > 0 {
+		fmt.Fprintf(b, "%dH", int64(hours))
+		duration -= hours * time.Hour
+	}
+	if minutes := duration / time.Minute; minutes > 0 {
+		fmt.Fprintf(b, "%dM", int64(minutes))
+		duration -= minutes * time.Minute
+	}
+	if duration > 0 {
+		fmt.Fprintf(b, "%sS", strconv.FormatFloat(duration.Seconds(), 'f', -1, 64))
+	}
+	return b.String()
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (d ISO8601Duration) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (d *ISO8601Duration) UnmarshalText(text []byte) error {
+	iso8601DurationRegexp := regexp.MustCompile(` + "`" + `\A(-)?P(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+(?:\.\d+)?)S)?)?\z` + "`" + `)
+	match := iso8601DurationRegexp.FindStringSubmatch(string(text))
+	if match == nil || !strings.ContainsAny(string(text), "0123456789") {
+		return fmt.Errorf("%q: invalid ISO 8601 duration", text)
+	}
+	var duration time.Duration
+	for i, unit := range []time.Duration{24 * time.Hour, time.Hour, time.Minute, time.Second} {
+		if match[i+2] == "" {
+			continue
+		}
+		f, err := strconv.ParseFloat(match[i+2], 64)
+		if err != nil {
+			return err
+		}
+		duration += time.Duration(f * float64(unit))
+	}
+	if match[1] == "-" {
+		duration = -duration
+	}
+	d.Duration = duration
+	return nil
+}`
+
+// observeDuration records whether s is a duration as parsed by
+// time.ParseDuration or, if enabled, an ISO 8601 duration.
+func (v *value) observeDuration(s string, options *observeOptions) {
+	if options.detectDurations && isDuration(s) {
+		v.durations++
+	}
+	if options.detectISO8601Durations && isISO8601Duration(s) {
+		v.iso8601Durations++
+	}
+}
+
+// isDuration returns true if s is a duration as parsed by time.ParseDuration
+// with a unit suffix. time.ParseDuration also accepts "0", which is more likely
+// to be a number.
+func isDuration(s string) bool {
+	_, err := time.ParseDuration(s)
+	return err == nil && strings.IndexFunc(s, unicode.IsLetter) != -1
+}
+
+// isISO8601Duration returns true if s is an ISO 8601 duration with at least one
+// component.
+func isISO8601Duration(s string) bool {
+	return iso8601DurationRegexp.MatchString(s) && strings.ContainsAny(s, "0123456789")
+}
+
+// durationTypeStr returns the Go type of the string v if all observed strings
+// are durations in the same format, and declares it. Otherwise it returns the
+// empty string.
+func (v *value) durationTypeStr(options *generateOptions) string {
+	switch {
+	case v.strings == 0:
+		return ""
+	case v.durations == v.strings:
+		options.imports["time"] = struct{}{}
+		options.declareHelper("Duration", durationHelperDecl)
+		return "Duration"
+	case v.iso8601Durations == v.strings:
+		options.imports["fmt"] = struct{}{}
+		options.imports["regexp"] = struct{}{}
+		options.imports["strconv"] = struct{}{}
+		options.imports["strings"] = struct{}{}
+		options.imports["time"] = struct{}{}
+		options.declareHelper("ISO8601Duration", iso8601DurationHelperDecl)
+		return "ISO8601Duration"
+	default:
+		return ""
+	}
+}
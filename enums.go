@@ -0,0 +1,217 @@
+package jsonstruct
+
+import (
+	"bytes"
+	"fmt"
+	"go/token"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// An enumConst is a constant of a generated enum type.
+type enumConst struct {
+	name    string
+	literal string
+}
+
+// observeStringValue records the distinct string value s, up to
+// options.maxStringEnumValues distinct values.
+func (v *value) observeStringValue(s string, options *observeOptions) {
+	if options.maxStringEnumValues == 0 || v.tooManyStringValues {
+		return
+	}
+	if _, ok := v.stringValues[s]; !ok && len(v.stringValues) >= options.maxStringEnumValues {
+		v.stringValues = nil
+		v.tooManyStringValues = true
+		return
+	}
+	if v.stringValues == nil {
+		v.stringValues = make(map[string]int)
+	}
+	v.stringValues[s]++
+}
+
+// observeIntValue records the distinct integer value i, up to
+// options.maxIntEnumValues distinct values.
+func (v *value) observeIntValue(i int64, options *observeOptions) {
+	if options.maxIntEnumValues == 0 || v.tooManyIntValues {
+		return
+	}
+	if _, ok := v.intValues[i]; !ok && len(v.intValues) >= options.maxIntEnumValues {
+		v.intValues = nil
+		v.tooManyIntValues = true
+		return
+	}
+	if v.intValues == nil {
+		v.intValues = make(map[int64]int)
+	}
+	v.intValues[i]++
+}
+
+// mergeValueCounts returns the merged counts of a and b.
+func mergeValueCounts[K comparable](a, b map[K]int) map[K]int {
+	if a == nil && b == nil {
+		return nil
+	}
+	merged := maps.Clone(a)
+	if merged == nil {
+		merged = make(map[K]int)
+	}
+	for k, count := range b {
+		merged[k] += count
+	}
+	return merged
+}
+
+// isEnum returns true if values, which were observed observations times,
+// should be an enum. Enums must have at least two distinct values and each
+// value must be observed, on average, at least twice.
+func isEnum[K comparable](values map[K]int, observations int) bool {
+	return len(values) >= 2 && 2*len(values) <= observations
+}
+
+// stringEnumTypeStr returns the Go type of the string enum v, which is located
+// at path, and declares it. It returns the empty string if v is not a string
+// enum.
+func (v *value) stringEnumTypeStr(path valuePath, options *generateOptions) string {
+	if v.tooManyStringValues || !isEnum(v.stringValues, v.strings) {
+		return ""
+	}
+	if _, ok := v.stringValues[""]; ok {
+		return ""
+	}
+	values := slices.Sorted(maps.Keys(v.stringValues))
+	enumConsts := make([]enumConst, 0, len(values))
+	for _, value := range values {
+		enumConsts = append(enumConsts, enumConst{
+			name:    options.exportNameFunc(value),
+			literal: strconv.Quote(value),
+		})
+	}
+	return options.declareEnum(path, "string", enumConsts)
+}
+
+// intEnumTypeStr returns the Go type of the integer enum v, which is located at
+// path, and declares it. It returns the empty string if v is not an integer
+// enum. Constant names are taken from options.intEnumNames if present,
+// otherwise they are derived from the value.
+func (v *value) intEnumTypeStr(path valuePath, options *generateOptions) string {
+	if v.tooManyIntValues || v.largeUints+v.bigInts > 0 || !isEnum(v.intValues, v.ints) {
+		return ""
+	}
+	names := options.intEnumNames[path.String()]
+	values := slices.Sorted(maps.Keys(v.intValues))
+	enumConsts := make([]enumConst, 0, len(values))
+	for _, value := range values {
+		name, ok := names[value]
+		switch {
+		case ok:
+		case value < 0:
+			name = "Minus" + strconv.FormatInt(-value, 10)
+		default:
+			name = strconv.FormatInt(value, 10)
+		}
+		enumConsts = append(enumConsts, enumConst{
+			name:    name,
+			literal: strconv.FormatInt(value, 10),
+		})
+	}
+	return options.declareEnum(path, v.intTypeStr(path, options), enumConsts)
+}
+
+// declareEnum declares an enum type with underlying type underlyingTypeStr and
+// constants enumConsts for the value at path and returns its Go type. The
+// constant names are prefixed with the enum type name and reserved so that they
+// do not collide with type names. Enums with the same
+// constants share a type, unless types are not deduplicated, in which case
+// only enums at the same path share a type.
+func (options *generateOptions) declareEnum(path valuePath, underlyingTypeStr string, enumConsts []enumConst) string {
+	name := path[0]
+	if len(path) > 1 {
+		var sb strings.Builder
+		if options.deduplicateTypes == DeduplicateTypesNever {
+			fmt.Fprintf(&sb, "%s\n", path)
+		}
+		fmt.Fprintf(&sb, "%s\n", underlyingTypeStr)
+		for _, enumConst := range enumConsts {
+			fmt.Fprintf(&sb, "%s %s\n", enumConst.name, enumConst.literal)
+		}
+		enumKey := sb.String()
+		if name, ok := options.enumTypeNames[enumKey]; ok {
+			return name
+		}
+		name = options.reserveTypeName(path)
+		if options.enumTypeNames == nil {
+			options.enumTypeNames = make(map[string]string)
+		}
+		options.enumTypeNames[enumKey] = name
+	}
+
+	b := &bytes.Buffer{}
+	fmt.Fprintf(b, "const (\n")
+	for i, enumConst := range enumConsts {
+		constName := name + enumConst.name
+		if !token.IsIdentifier(constName) {
+			constName = name + "Value"
+		}
+		for base, n := constName, 2; !options.isTypeNameAvailable(path, constName); n++ {
+			constName = base + strconv.Itoa(n)
+		}
+		options.declareHelper(constName, "")
+		enumConsts[i].name = constName
+		fmt.Fprintf(b, "%s %s = %s\n", constName, name, enumConst.literal)
+	}
+	fmt.Fprintf(b, ")")
+
+	if options.enumValidation {
+		options.imports["fmt"] = struct{}{}
+		fmt.Fprintf(b, "\n\n// IsValid returns true if v is a known %s.\n", name)
+		fmt.Fprintf(b, "func (v %s) IsValid() bool {\n", name)
+		fmt.Fprintf(b, "switch v {\n")
+		fmt.Fprintf(b, "case ")
+		for i, enumConst := range enumConsts {
+			if i > 0 {
+				fmt.Fprintf(b, ", ")
+			}
+			fmt.Fprintf(b, "%s", enumConst.name)
+		}
+		fmt.Fprintf(b, ":\n")
+		fmt.Fprintf(b, "return true\n")
+		fmt.Fprintf(b, "default:\n")
+		fmt.Fprintf(b, "return false\n")
+		fmt.Fprintf(b, "}\n")
+		fmt.Fprintf(b, "}\n")
+		if underlyingTypeStr == "string" {
+			fmt.Fprintf(b, "\n// UnmarshalText implements encoding.TextUnmarshaler.\n")
+			fmt.Fprintf(b, "func (v *%s) UnmarshalText(text []byte) error {\n", name)
+			fmt.Fprintf(b, "value := %s(text)\n", name)
+			fmt.Fprintf(b, "if !value.IsValid() {\n")
+			fmt.Fprintf(b, "return fmt.Errorf(\"%%q: invalid %s\", text)\n", name)
+			fmt.Fprintf(b, "}\n")
+		} else {
+			// Numbers are not unmarshalled with encoding.TextUnmarshaler.
+			options.imports["encoding/json"] = struct{}{}
+			fmt.Fprintf(b, "\n// UnmarshalJSON implements encoding/json.Unmarshaler.\n")
+			fmt.Fprintf(b, "func (v *%s) UnmarshalJSON(data []byte) error {\n", name)
+			// Unlike UnmarshalText, UnmarshalJSON is called for null,
+			// which encoding/json expects to be a no-op.
+			fmt.Fprintf(b, "if string(data) == \"null\" {\n")
+			fmt.Fprintf(b, "return nil\n")
+			fmt.Fprintf(b, "}\n")
+			fmt.Fprintf(b, "var underlying %s\n", underlyingTypeStr)
+			fmt.Fprintf(b, "if err := json.Unmarshal(data, &underlying); err != nil {\n")
+			fmt.Fprintf(b, "return err\n")
+			fmt.Fprintf(b, "}\n")
+			fmt.Fprintf(b, "value := %s(underlying)\n", name)
+			fmt.Fprintf(b, "if !value.IsValid() {\n")
+			fmt.Fprintf(b, "return fmt.Errorf(\"%%d: invalid %s\", underlying)\n", name)
+			fmt.Fprintf(b, "}\n")
+		}
+		fmt.Fprintf(b, "*v = value\n")
+		fmt.Fprintf(b, "return nil\n")
+		fmt.Fprintf(b, "}")
+	}
+	return options.declareReservedType(path, name, underlyingTypeStr, b.String())
+}
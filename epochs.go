@@ -0,0 +1,101 @@
+package jsonstruct
+
+import (
+	"fmt"
+)
+
+// An epochUnit is a unit of time since the Unix epoch.
+type epochUnit struct {
+	typeName      string
+	description   string
+	perSecond     int64
+	marshalExpr   string
+	unmarshalExpr string
+}
+
+// epochUnits are the recognized epoch units.
+var epochUnits = []epochUnit{
+	{
+		typeName:      "UnixTime",
+		description:   "seconds",
+		perSecond:     1,
+		marshalExpr:   "t.Unix()",
+		unmarshalExpr: "time.Unix(i, 0)",
+	},
+	{
+		typeName:      "UnixMilliTime",
+		description:   "milliseconds",
+		perSecond:     1e3,
+		marshalExpr:   "t.UnixMilli()",
+		unmarshalExpr: "time.UnixMilli(i)",
+	},
+	{
+		typeName:      "UnixMicroTime",
+		description:   "microseconds",
+		perSecond:     1e6,
+		marshalExpr:   "t.UnixMicro()",
+		unmarshalExpr: "time.UnixMicro(i)",
+	},
+	{
+		typeName:      "UnixNanoTime",
+		description:   "nanoseconds",
+		perSecond:     1e9,
+		marshalExpr:   "t.UnixNano()",
+		unmarshalExpr: "time.Unix(0, i)",
+	},
+}
+
+// Plausible epoch times are between 1990-01-01 and 2100-01-01.
+const (
+	minPlausibleEpochSeconds = 631152000
+	maxPlausibleEpochSeconds = 4102444800
+)
+
+const epochHelperDeclFormat = `// A %[1]s is a time.Time marshalled as %[2]s since the Unix epoch.
+type %[1]s struct {
+	time.Time
+}
+
+// MarshalJSON implements encoding/json.Marshaler.
+func (t %[1]s) MarshalJSON() ([]byte, error) {
+	return json.Marshal(%[3]s)
+}
+
+// UnmarshalJSON implements encoding/json.Unmarshaler.
+func (t *%[1]s) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	var i int64
+	if err := json.Unmarshal(data, &i); err != nil {
+		return err
+	}
+	t.Time = %[4]s
+	return nil
+}`
+
+// epochTimeTypeStr returns the Go type of the integer v, which is located at
+// path, if its property name matches options.epochTimeSuffixes and all its
+// values are plausible times in the same unit since the Unix epoch, and
+// declares it. Otherwise it returns the empty string.
+func (v *value) epochTimeTypeStr(path valuePath, options *generateOptions) string {
+	if !options.detectEpochTimes || v.ints == 0 || v.largeUints+v.bigInts > 0 {
+		return ""
+	}
+	i := path.propertyIndex()
+	if i == 0 || !hasSuffixComponents(path[i], options.epochTimeSuffixes) {
+		return ""
+	}
+	for _, epochUnit := range epochUnits {
+		if v.minInt < minPlausibleEpochSeconds*epochUnit.perSecond || v.maxInt > maxPlausibleEpochSeconds*epochUnit.perSecond {
+			continue
+		}
+		options.imports["encoding/json"] = struct{}{}
+		options.imports["time"] = struct{}{}
+		options.declareHelper(epochUnit.typeName, fmt.Sprintf(epochHelperDeclFormat,
+			epochUnit.typeName, epochUnit.description, epochUnit.marshalExpr, epochUnit.unmarshalExpr,
+		))
+		return epochUnit.typeName
+	}
+	return ""
+}
@@ -0,0 +1,28 @@
+package jsonstruct
+
+import (
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/alecthomas/assert/v2"
+)
+
+func TestEpochTimeSuffixes(t *testing.T) {
+	suffixes := []string{"_at", "_time", "timestamp", "ts"}
+	expected := map[string]bool{
+		"createdAt":      true,
+		"created_at":     true,
+		"eventTimestamp": true,
+		"format":         false,
+		"start-time":     true,
+		"timestamp":      true,
+		"ts":             true,
+		"updated":        false,
+	}
+	for _, property := range slices.Sorted(maps.Keys(expected)) {
+		t.Run(property, func(t *testing.T) {
+			assert.Equal(t, expected[property], hasSuffixComponents(property, suffixes))
+		})
+	}
+}
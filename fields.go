@@ -0,0 +1,87 @@
+package jsonstruct
+
+import (
+	"maps"
+	"slices"
+	"strconv"
+)
+
+// A fieldName is the Go name of the struct field for a property.
+type fieldName struct {
+	name string
+	// renamed is true if the field was renamed because its name collided
+	// with the field of the property collidesWith or, if collidesWithMethod
+	// is true, with the method collidesWith.
+	renamed            bool
+	collidesWith       string
+	collidesWithMethod bool
+}
+
+// fieldNames returns the Go field names of properties of the object at path.
+// Properties whose exported names collide are disambiguated deterministically:
+// the property whose name is exactly its exported name keeps it, or else the
+// first property in sorted order keeps it, and the other properties have
+// numeric suffixes appended, starting at 2. Properties whose exported names
+// collide with one of methodNames, the names of the struct's methods, all have
+// numeric suffixes appended.
+func (options *generateOptions) fieldNames(path valuePath, properties, methodNames []string) map[string]fieldName {
+	propertiesByName := make(map[string][]string)
+	for _, property := range slices.Sorted(slices.Values(properties)) {
+		name := options.exportName(path.appendProperty(property))
+		propertiesByName[name] = append(propertiesByName[name], property)
+	}
+
+	fieldNames := make(map[string]fieldName, len(properties))
+	for _, name := range slices.Sorted(maps.Keys(propertiesByName)) {
+		collidingProperties := propertiesByName[name]
+		isMethodName := slices.Contains(methodNames, name)
+		// keptIndex is the index of the property that keeps name, or -1 if
+		// no property keeps it. The empty string is a valid property, so it
+		// cannot indicate that no property keeps name.
+		keptIndex := -1
+		switch {
+		case isMethodName:
+		case slices.Contains(collidingProperties, name):
+			keptIndex = slices.Index(collidingProperties, name)
+		default:
+			keptIndex = 0
+		}
+		if keptIndex >= 0 {
+			fieldNames[collidingProperties[keptIndex]] = fieldName{
+				name: name,
+			}
+		}
+		n := 2
+		for i, property := range collidingProperties {
+			if i == keptIndex {
+				continue
+			}
+			var suffixedName string
+			for {
+				suffixedName = name + strconv.Itoa(n)
+				n++
+				_, ok := propertiesByName[suffixedName]
+				if !ok && !slices.Contains(methodNames, suffixedName) {
+					break
+				}
+			}
+			// Reserve suffixedName so that it is not used again.
+			propertiesByName[suffixedName] = nil
+			if isMethodName {
+				fieldNames[property] = fieldName{
+					name:               suffixedName,
+					renamed:            true,
+					collidesWith:       name,
+					collidesWithMethod: true,
+				}
+			} else {
+				fieldNames[property] = fieldName{
+					name:         suffixedName,
+					renamed:      true,
+					collidesWith: collidingProperties[keptIndex],
+				}
+			}
+		}
+	}
+	return fieldNames
+}
@@ -0,0 +1,78 @@
+package jsonstruct
+
+import (
+	"testing"
+
+	"github.com/alecthomas/assert/v2"
+)
+
+func TestFieldNames(t *testing.T) {
+	options := &generateOptions{
+		exportNameFunc: func(name string) string {
+			return DefaultExportNameFunc(name, defaultAbbreviations)
+		},
+	}
+	for _, tc := range []struct {
+		name        string
+		properties  []string
+		methodNames []string
+		expected    map[string]fieldName
+	}{
+		{
+			name:       "no_collisions",
+			properties: []string{"id", "name"},
+			expected: map[string]fieldName{
+				"id":   {name: "ID"},
+				"name": {name: "Name"},
+			},
+		},
+		{
+			name:       "exact_match",
+			properties: []string{"ID", "id"},
+			expected: map[string]fieldName{
+				"ID": {name: "ID"},
+				"id": {name: "ID2", renamed: true, collidesWith: "ID"},
+			},
+		},
+		{
+			name:       "sorted_order",
+			properties: []string{"user_id", "userId", "user-id"},
+			expected: map[string]fieldName{
+				"user-id": {name: "UserID"},
+				"userId":  {name: "UserID2", renamed: true, collidesWith: "user-id"},
+				"user_id": {name: "UserID3", renamed: true, collidesWith: "user-id"},
+			},
+		},
+		{
+			name:       "suffix_collision",
+			properties: []string{"foo-bar", "foo_bar", "fooBar2"},
+			expected: map[string]fieldName{
+				"foo-bar": {name: "FooBar"},
+				"fooBar2": {name: "FooBar2"},
+				"foo_bar": {name: "FooBar3", renamed: true, collidesWith: "foo-bar"},
+			},
+		},
+		{
+			name:       "empty",
+			properties: []string{"", "a"},
+			expected: map[string]fieldName{
+				"":  {name: "TProperty"},
+				"a": {name: "A"},
+			},
+		},
+		{
+			name:        "method_collision",
+			properties:  []string{"MarshalJSON", "marshal_json", "id"},
+			methodNames: []string{"MarshalJSON", "UnmarshalJSON"},
+			expected: map[string]fieldName{
+				"MarshalJSON":  {name: "MarshalJSON2", renamed: true, collidesWith: "MarshalJSON", collidesWithMethod: true},
+				"id":           {name: "ID"},
+				"marshal_json": {name: "MarshalJSON3", renamed: true, collidesWith: "MarshalJSON", collidesWithMethod: true},
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, options.fieldNames(valuePath{"T"}, tc.properties, tc.methodNames))
+		})
+	}
+}
@@ -0,0 +1,192 @@
+package jsonstruct
+
+import (
+	"net"
+	"net/netip"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// A StringFormat detects strings in a well-known format.
+type StringFormat struct {
+	// Name is the name of the format, used in comments.
+	Name string
+	// Match returns true if a string is in the format.
+	Match func(string) bool
+	// TypeStr is the Go type of strings in the format, which should implement
+	// encoding.TextMarshaler and encoding.TextUnmarshaler. If it is empty then
+	// strings in the format are generated as strings with a comment naming the
+	// format.
+	TypeStr string
+	// Imports are the packages that TypeStr requires.
+	Imports []string
+}
+
+var (
+	emailAddressRegexp = regexp.MustCompile(`\A[^\s@<>:]+@[^\s@<>:]+\.[^\s@<>:]+\z`)
+	hostnameRegexp     = regexp.MustCompile(`\A(?i:(?:[0-9a-z](?:[0-9a-z-]*[0-9a-z])?\.)+([a-z]{2,63}|xn--[0-9a-z-]+))\z`)
+)
+
+// fileExtensions are common file extensions. Names whose top-level domain is a
+// file extension, like config.yaml, are not considered to be hostnames.
+var fileExtensions = map[string]bool{
+	"bak":  true,
+	"bin":  true,
+	"bmp":  true,
+	"cfg":  true,
+	"conf": true,
+	"css":  true,
+	"csv":  true,
+	"dat":  true,
+	"db":   true,
+	"doc":  true,
+	"docx": true,
+	"env":  true,
+	"exe":  true,
+	"gif":  true,
+	"go":   true,
+	"gz":   true,
+	"htm":  true,
+	"html": true,
+	"ini":  true,
+	"jar":  true,
+	"java": true,
+	"jpeg": true,
+	"jpg":  true,
+	"js":   true,
+	"json": true,
+	"jsx":  true,
+	"lock": true,
+	"log":  true,
+	"md":   true,
+	"mp3":  true,
+	"mp4":  true,
+	"pdf":  true,
+	"php":  true,
+	"png":  true,
+	"py":   true,
+	"rb":   true,
+	"rs":   true,
+	"sh":   true,
+	"so":   true,
+	"sql":  true,
+	"svg":  true,
+	"tar":  true,
+	"tgz":  true,
+	"tmp":  true,
+	"toml": true,
+	"ts":   true,
+	"tsx":  true,
+	"txt":  true,
+	"wav":  true,
+	"xls":  true,
+	"xlsx": true,
+	"xml":  true,
+	"yaml": true,
+	"yml":  true,
+	"zip":  true,
+}
+
+// DefaultStringFormats returns the default string formats. Earlier formats take
+// precedence.
+func DefaultStringFormats() []StringFormat {
+	return []StringFormat{
+		{
+			Name:  "UUID",
+			Match: uuidRegexp.MatchString,
+		},
+		{
+			Name:    "IP address",
+			Match:   isIPAddress,
+			TypeStr: "netip.Addr",
+			Imports: []string{"net/netip"},
+		},
+		{
+			Name:    "CIDR prefix",
+			Match:   isCIDRPrefix,
+			TypeStr: "netip.Prefix",
+			Imports: []string{"net/netip"},
+		},
+		{
+			Name:  "MAC address",
+			Match: isMACAddress,
+		},
+		{
+			Name:  "URL",
+			Match: isAbsoluteURL,
+		},
+		{
+			Name:  "email address",
+			Match: emailAddressRegexp.MatchString,
+		},
+		{
+			Name:  "hostname",
+			Match: isHostname,
+		},
+	}
+}
+
+// isHostname returns true if s is a hostname with at least two labels whose
+// top-level domain consists of letters and is not a common file extension.
+func isHostname(s string) bool {
+	match := hostnameRegexp.FindStringSubmatch(s)
+	return match != nil && !fileExtensions[strings.ToLower(match[1])]
+}
+
+// isIPAddress returns true if s is an IPv4 or IPv6 address.
+func isIPAddress(s string) bool {
+	_, err := netip.ParseAddr(s)
+	return err == nil
+}
+
+// isCIDRPrefix returns true if s is an IPv4 or IPv6 prefix in CIDR notation.
+func isCIDRPrefix(s string) bool {
+	_, err := netip.ParsePrefix(s)
+	return err == nil
+}
+
+// isMACAddress returns true if s is a MAC address.
+func isMACAddress(s string) bool {
+	_, err := net.ParseMAC(s)
+	return err == nil
+}
+
+// isAbsoluteURL returns true if s is an absolute URL with a host.
+func isAbsoluteURL(s string) bool {
+	u, err := url.Parse(s)
+	return err == nil && u.IsAbs() && u.Host != ""
+}
+
+// observeStringFormats records which of options.stringFormats s matches.
+func (v *value) observeStringFormats(s string, options *observeOptions) {
+	for _, stringFormat := range options.stringFormats {
+		if stringFormat.Match(s) {
+			if v.stringFormats == nil {
+				v.stringFormats = make(map[string]int)
+			}
+			v.stringFormats[stringFormat.Name]++
+		}
+	}
+}
+
+// stringFormat returns the first of options.stringFormats that all observed
+// strings match, adding its imports if it has a Go type, or nil if there is no
+// such format.
+func (v *value) stringFormat(options *generateOptions) *StringFormat {
+	if v.strings == 0 {
+		return nil
+	}
+	for i, stringFormat := range options.stringFormats {
+		if v.stringFormats[stringFormat.Name] != v.strings {
+			continue
+		}
+		if stringFormat.TypeStr != "" {
+			for _, _import := range stringFormat.Imports {
+				options.imports[_import] = struct{}{}
+			}
+		}
+		return &options.stringFormats[i]
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package jsonstruct
+
+import (
+	"testing"
+
+	"github.com/alecthomas/assert/v2"
+)
+
+func TestDefaultStringFormats(t *testing.T) {
+	for _, tc := range []struct {
+		s        string
+		expected string
+	}{
+		{s: "", expected: ""},
+		{s: "1.5", expected: ""},
+		{s: "123e4567-e89b-12d3-a456-426614174000", expected: "UUID"},
+		{s: "192.168.0.1", expected: "IP address"},
+		{s: "2001:db8::1", expected: "IP address"},
+		{s: "10.0.0.0/8", expected: "CIDR prefix"},
+		{s: "00:00:5e:00:53:01", expected: "MAC address"},
+		{s: "https://example.com/path", expected: "URL"},
+		{s: "mailto:user@example.com", expected: ""},
+		{s: "user@example.com", expected: "email address"},
+		{s: "www.example.com", expected: "hostname"},
+		{s: "xn--e1afmkfd.xn--p1ai", expected: "hostname"},
+		{s: "config.yaml", expected: ""},
+		{s: "data.JSON", expected: ""},
+		{s: "v1.2", expected: ""},
+		{s: "example.c0m", expected: ""},
+		{s: "localhost", expected: ""},
+	} {
+		t.Run(tc.s, func(t *testing.T) {
+			actual := ""
+			for _, stringFormat := range DefaultStringFormats() {
+				if stringFormat.Match(tc.s) {
+					actual = stringFormat.Name
+					break
+				}
+			}
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
@@ -10,7 +10,6 @@ import (
 	"maps"
 	"os"
 	"slices"
-	"strings"
 
 	"github.com/goccy/go-yaml"
 )
@@ -38,24 +37,175 @@ const (
 	OmitZeroTagsAuto
 )
 
+// An IntTypesType sets how integer types are chosen.
+type IntTypesType int
+
+// IntTypes values.
+const (
+	// IntTypesFixed always uses the integer type.
+	IntTypesFixed IntTypesType = iota
+	// IntTypesExact uses the smallest signed or unsigned type that fits all
+	// observed values. Array elements are never uint8s, because
+	// encoding/json marshals []uint8 as a base64 string.
+	IntTypesExact
+	// IntTypesSigned uses the smallest signed type that fits all observed
+	// values.
+	IntTypesSigned
+	// IntTypesWiden uses the integer type if all observed values fit in 32
+	// bits, and int64 otherwise.
+	IntTypesWiden
+)
+
+// A LargeIntsType sets how integers that do not fit in an int64 are handled.
+type LargeIntsType int
+
+// LargeInts values.
+const (
+	// LargeIntsFloat64 treats integers that do not fit in an int64 as
+	// float64s, which may lose precision.
+	LargeIntsFloat64 LargeIntsType = iota
+	// LargeIntsJSONNumber generates uint64 for non-negative integers that fit
+	// in a uint64 and json.Number otherwise.
+	LargeIntsJSONNumber
+	// LargeIntsBigInt generates uint64 for non-negative integers that fit in a
+	// uint64 and *big.Int otherwise.
+	LargeIntsBigInt
+)
+
+// A NumbersType sets how integers and floats are normalized.
+type NumbersType int
+
+// Numbers values.
+const (
+	// NumbersAsObserved uses the observed integers and floats.
+	NumbersAsObserved NumbersType = iota
+	// NumbersIntegralFloatsAsInts treats floats as integers if all observed
+	// floats, like 1.0, are integers. encoding/json cannot unmarshal floats
+	// into Go integer types, so a helper type is generated for them.
+	NumbersIntegralFloatsAsInts
+	// NumbersFloatsAsFloat64 generates float64 for all numbers if any
+	// observed number is a float.
+	NumbersFloatsAsFloat64
+	// NumbersAllFloat64 generates float64 for all numbers.
+	NumbersAllFloat64
+)
+
+// A NullableTypesType sets how nullable strings, numbers, booleans, and times
+// are generated.
+type NullableTypesType int
+
+// NullableTypes values.
+const (
+	// NullableTypesPointers generates pointers, like *string.
+	NullableTypesPointers NullableTypesType = iota
+	// NullableTypesSQL generates helper types that embed database/sql Null
+	// types, like sql.NullString, and are marshalled to JSON as plain values
+	// or null.
+	NullableTypesSQL
+)
+
+// A PointersType sets when pointers are used for properties that may be absent
+// or null.
+type PointersType int
+
+// Pointers values.
+const (
+	// PointersAuto uses pointers for nullable values and for objects that may
+	// be absent.
+	PointersAuto PointersType = iota
+	// PointersAbsentOrNull uses pointers for all values that may be absent or
+	// null.
+	PointersAbsentOrNull
+	// PointersNullOnly uses pointers only for values that may be null.
+	PointersNullOnly
+	// PointersNever never uses pointers and uses ,omitzero tags for values
+	// that may be absent or null instead.
+	PointersNever
+)
+
+// An UnparsablePropertiesType sets how properties whose names cannot be used as
+// encoding/json struct tag names, for example because they are empty or
+// contain spaces, commas, quotes, or backticks, are handled.
+type UnparsablePropertiesType int
+
+// UnparsableProperties values.
+const (
+	// UnparsablePropertiesComment skips properties that are empty or contain
+	// spaces, commas, quotes, or backticks and notes them in a comment or,
+	// according to WithSkipUnparsableProperties, generates a map if any
+	// property contains a space. Other unparsable properties, like "€", are
+	// generated as fields.
+	UnparsablePropertiesComment UnparsablePropertiesType = iota
+	// UnparsablePropertiesMethods generates fields that are skipped by
+	// encoding/json and MarshalJSON and UnmarshalJSON methods that marshal
+	// them. Marshalled objects have their properties sorted, and properties
+	// that may be absent are omitted if they are zero.
+	UnparsablePropertiesMethods
+)
+
+// A DeduplicateTypesType sets how nested types are deduplicated.
+type DeduplicateTypesType int
+
+// DeduplicateTypes values.
+const (
+	DeduplicateTypesNever DeduplicateTypesType = iota
+	DeduplicateTypesIdentical
+	DeduplicateTypesCompatible
+)
+
 // A Generator generates Go types from observed values.
 type Generator struct {
 	abbreviations            map[string]bool
+	decimalImport            string
+	decimalNames             []string
+	decimalType              string
+	deduplicateTypes         DeduplicateTypesType
+	detectBase64             bool
+	detectBase64Variants     bool
+	detectDecimals           bool
+	detectDurations          bool
+	detectEpochTimes         bool
+	detectISO8601Durations   bool
+	detectMaps               bool
+	detectRecursiveTypes     bool
+	detectTuples             bool
+	detectUnions             bool
+	discriminators           []string
+	enumValidation           bool
+	epochTimeSuffixes        []string
 	exportNameFunc           ExportNameFunc
 	exportRenames            map[string]string
+	extractNestedTypes       bool
 	fileHeader               string
 	goFormat                 bool
 	imports                  map[string]struct{}
+	intEnumNames             map[string]map[int64]string
 	intType                  string
+	intTypes                 IntTypesType
+	largeInts                LargeIntsType
+	mapOverrides             map[string]bool
+	mapThreshold             int
+	maxIntEnumValues         int
+	maxStringEnumValues      int
+	nullableTypes            NullableTypesType
+	numbers                  NumbersType
+	numbersOverrides         map[string]NumbersType
 	omitEmptyTags            OmitEmptyTagsType
 	omitZeroTags             OmitZeroTagsType
+	optionalTypes            bool
 	packageComment           string
 	packageName              string
+	pointers                 PointersType
+	polymorphicTypes         bool
 	skipUnparsableProperties bool
+	stringFormats            []StringFormat
 	stringTags               bool
 	structTagNames           []string
+	timeLayouts              []string
 	typeComment              string
 	typeName                 string
+	typedMapKeys             bool
+	unparsableProperties     UnparsablePropertiesType
 	useJSONNumber            bool
 	value                    *value
 }
@@ -73,6 +223,157 @@ func WithAbbreviations(abbreviations ...string) GeneratorOption {
 	}
 }
 
+// WithDecimalNames sets the property name suffixes, for example "price" or
+// "amount", that indicate decimals. Suffixes are compared by name component,
+// ignoring case.
+func WithDecimalNames(decimalNames ...string) GeneratorOption {
+	return func(g *Generator) {
+		g.decimalNames = decimalNames
+	}
+}
+
+// WithDecimalType sets the Go type of decimals, for example
+// "decimal.Decimal", and the import path of the package that defines it, which
+// may be empty. The type must unmarshal from JSON numbers. The default is
+// json.Number.
+func WithDecimalType(decimalType, decimalImport string) GeneratorOption {
+	return func(g *Generator) {
+		g.decimalType = decimalType
+		g.decimalImport = decimalImport
+	}
+}
+
+// WithDeduplicateTypes sets whether structurally identical nested objects, and
+// optionally compatible nested objects, should share a single named type.
+// Compatible objects are merged into a single type with optional fields.
+// Deduplicated nested objects are always extracted into named types.
+func WithDeduplicateTypes(deduplicateTypes DeduplicateTypesType) GeneratorOption {
+	return func(g *Generator) {
+		g.deduplicateTypes = deduplicateTypes
+	}
+}
+
+// WithDetectBase64 sets whether strings that are always long, valid, standard
+// base64 should be generated as []byte, which encoding/json unmarshals
+// natively.
+func WithDetectBase64(detectBase64 bool) GeneratorOption {
+	return func(g *Generator) {
+		g.detectBase64 = detectBase64
+	}
+}
+
+// WithDetectBase64Variants sets whether strings that are always long, valid,
+// URL-safe or unpadded base64 should be generated as helper types that marshal
+// and unmarshal a []byte. It has no effect unless base64 is detected.
+func WithDetectBase64Variants(detectBase64Variants bool) GeneratorOption {
+	return func(g *Generator) {
+		g.detectBase64Variants = detectBase64Variants
+	}
+}
+
+// WithDetectDecimals sets whether numbers that are likely to be decimals, like
+// prices, should be generated as the decimal type rather than float64.
+// Numbers are likely to be decimals if their property name matches the
+// decimal names or if their non-integer values always have the same number of
+// fractional digits, between two and four.
+func WithDetectDecimals(detectDecimals bool) GeneratorOption {
+	return func(g *Generator) {
+		g.detectDecimals = detectDecimals
+	}
+}
+
+// WithDetectDurations sets whether strings that are durations, as parsed by
+// time.ParseDuration, like "30s" or "1h30m", should be generated as a helper
+// type that marshals and unmarshals a time.Duration.
+func WithDetectDurations(detectDurations bool) GeneratorOption {
+	return func(g *Generator) {
+		g.detectDurations = detectDurations
+	}
+}
+
+// WithDetectEpochTimes sets whether integer properties whose names match the
+// epoch time suffixes and whose values are plausible times since the Unix
+// epoch, in seconds, milliseconds, microseconds, or nanoseconds, should be
+// generated as helper types that unmarshal into a time.Time.
+func WithDetectEpochTimes(detectEpochTimes bool) GeneratorOption {
+	return func(g *Generator) {
+		g.detectEpochTimes = detectEpochTimes
+	}
+}
+
+// WithDetectISO8601Durations sets whether strings that are ISO 8601 durations,
+// like "PT5M", should be generated as a helper type that marshals and
+// unmarshals a time.Duration.
+func WithDetectISO8601Durations(detectISO8601Durations bool) GeneratorOption {
+	return func(g *Generator) {
+		g.detectISO8601Durations = detectISO8601Durations
+	}
+}
+
+// WithDetectMaps sets whether objects with dynamic property names, like IDs,
+// hostnames, dates, or numbers, should be generated as maps rather than
+// structs.
+func WithDetectMaps(detectMaps bool) GeneratorOption {
+	return func(g *Generator) {
+		g.detectMaps = detectMaps
+	}
+}
+
+// WithDetectRecursiveTypes sets whether nested objects that repeat one of their
+// ancestors, like the children of nodes in a tree, should be generated as a
+// single self-referential named type.
+func WithDetectRecursiveTypes(detectRecursiveTypes bool) GeneratorOption {
+	return func(g *Generator) {
+		g.detectRecursiveTypes = detectRecursiveTypes
+	}
+}
+
+// WithDetectTuples sets whether arrays that always have the same length, up to
+// eight elements, should be generated as fixed-length arrays if their elements
+// have the same type or as tuple structs if their elements have different
+// types at each position. Fixed-length arrays are only generated if at least
+// three arrays are observed.
+func WithDetectTuples(detectTuples bool) GeneratorOption {
+	return func(g *Generator) {
+		g.detectTuples = detectTuples
+	}
+}
+
+// WithDetectUnions sets whether objects whose properties depend on the value of
+// a string property with few distinct values should be generated as
+// discriminated unions.
+func WithDetectUnions(detectUnions bool) GeneratorOption {
+	return func(g *Generator) {
+		g.detectUnions = detectUnions
+	}
+}
+
+// WithDiscriminators sets the names of properties that, if always present with
+// a string value, are used to generate discriminated unions.
+func WithDiscriminators(discriminators ...string) GeneratorOption {
+	return func(g *Generator) {
+		g.discriminators = discriminators
+	}
+}
+
+// WithEnumValidation sets whether generated enum types should have IsValid
+// methods and reject unknown values when unmarshalling.
+func WithEnumValidation(enumValidation bool) GeneratorOption {
+	return func(g *Generator) {
+		g.enumValidation = enumValidation
+	}
+}
+
+// WithEpochTimeSuffixes sets the property name suffixes, for example "_at" or
+// "timestamp", that indicate epoch times. Suffixes are compared by name
+// component, ignoring case, so "_at" matches "created_at" and "createdAt" but
+// not "format".
+func WithEpochTimeSuffixes(epochTimeSuffixes ...string) GeneratorOption {
+	return func(g *Generator) {
+		g.epochTimeSuffixes = epochTimeSuffixes
+	}
+}
+
 // WithExportNameFunc sets the export name function.
 func WithExportNameFunc(exportNameFunc ExportNameFunc) GeneratorOption {
 	return func(g *Generator) {
@@ -89,6 +390,14 @@ func WithExtraAbbreviations(abbreviations ...string) GeneratorOption {
 	}
 }
 
+// WithExtractNestedTypes sets whether nested objects should be generated as
+// separate named types rather than as anonymous structs.
+func WithExtractNestedTypes(extractNestedTypes bool) GeneratorOption {
+	return func(g *Generator) {
+		g.extractNestedTypes = extractNestedTypes
+	}
+}
+
 // WithFileHeader sets the file header.
 func WithFileHeader(fileHeader string) GeneratorOption {
 	return func(g *Generator) {
@@ -103,6 +412,24 @@ func WithGoFormat(goFormat bool) GeneratorOption {
 	}
 }
 
+// WithIntEnumNames sets the names of the constants of integer enums. The keys
+// are paths, of the form "T.property.items[].property", and the values map
+// integer values to names.
+func WithIntEnumNames(intEnumNames map[string]map[int64]string) GeneratorOption {
+	return func(g *Generator) {
+		maps.Copy(g.intEnumNames, intEnumNames)
+	}
+}
+
+// WithIntEnums sets the maximum number of distinct values of an integer
+// property for it to be generated as an enum type with constants. Zero disables
+// integer enums.
+func WithIntEnums(maxIntEnumValues int) GeneratorOption {
+	return func(g *Generator) {
+		g.maxIntEnumValues = maxIntEnumValues
+	}
+}
+
 // WithIntType sets the integer type.
 func WithIntType(intType string) GeneratorOption {
 	return func(g *Generator) {
@@ -110,6 +437,76 @@ func WithIntType(intType string) GeneratorOption {
 	}
 }
 
+// WithIntTypes sets how integer types are chosen from the observed minimum and
+// maximum values. Integers greater than math.MaxInt64 are generated as uint64
+// unless negative integers are also observed. With LargeIntsFloat64, integers
+// in JSON that do not fit in an int64 are observed as float64s, so they are
+// generated as float64.
+func WithIntTypes(intTypes IntTypesType) GeneratorOption {
+	return func(g *Generator) {
+		g.intTypes = intTypes
+	}
+}
+
+// WithLargeInts sets how integers that do not fit in an int64 are handled.
+// Unless large integers are treated as float64s, properties that mix floats
+// with integers that cannot be represented exactly by a float64 are generated
+// as json.Number and, with string tags, strings containing integers that fit
+// in a uint64 are generated as uint64.
+func WithLargeInts(largeInts LargeIntsType) GeneratorOption {
+	return func(g *Generator) {
+		g.largeInts = largeInts
+	}
+}
+
+// WithMapOverrides sets whether the objects at the given paths should be
+// generated as maps (true) or structs (false), overriding automatic map
+// detection and the maps generated for objects with property names containing
+// spaces. Paths are of the form "T.property.items[].property", where T is the
+// type name.
+func WithMapOverrides(mapOverrides map[string]bool) GeneratorOption {
+	return func(g *Generator) {
+		maps.Copy(g.mapOverrides, mapOverrides)
+	}
+}
+
+// WithMapThreshold sets the minimum number of distinct property names for an
+// object whose property names do not look like dynamic keys to be detected as
+// a map.
+func WithMapThreshold(mapThreshold int) GeneratorOption {
+	return func(g *Generator) {
+		g.mapThreshold = mapThreshold
+	}
+}
+
+// WithNullableTypes sets how nullable strings, numbers, booleans, and times
+// are generated. With NullableTypesSQL, strings, int64s, float64s, booleans,
+// and times are generated as helper types that embed sql.NullString,
+// sql.NullInt64, sql.NullFloat64, sql.NullBool, and sql.NullTime, and other
+// types are generated as a helper type that embeds sql.Null[T], so that they
+// can be used with both encoding/json and database/sql.
+func WithNullableTypes(nullableTypes NullableTypesType) GeneratorOption {
+	return func(g *Generator) {
+		g.nullableTypes = nullableTypes
+	}
+}
+
+// WithNumbers sets how integers and floats are normalized.
+func WithNumbers(numbers NumbersType) GeneratorOption {
+	return func(g *Generator) {
+		g.numbers = numbers
+	}
+}
+
+// WithNumbersOverrides sets how integers and floats are normalized at the given
+// paths, overriding WithNumbers. Paths are of the form
+// "T.property.items[].property", where T is the type name.
+func WithNumbersOverrides(numbersOverrides map[string]NumbersType) GeneratorOption {
+	return func(g *Generator) {
+		maps.Copy(g.numbersOverrides, numbersOverrides)
+	}
+}
+
 // WithOmitEmptyTags sets whether ",omitempty" tags should be used.
 func WithOmitEmptyTags(omitEmptyTags OmitEmptyTagsType) GeneratorOption {
 	return func(g *Generator) {
@@ -124,6 +521,16 @@ func WithOmitZeroTags(omitZeroTags OmitZeroTagsType) GeneratorOption {
 	}
 }
 
+// WithOptionalTypes sets whether properties that are observed to be absent,
+// null, and other values should be generated as a generic Optional[T] helper
+// type that distinguishes all three, for example for PATCH requests. Optional
+// fields always have ,omitzero tags.
+func WithOptionalTypes(optionalTypes bool) GeneratorOption {
+	return func(g *Generator) {
+		g.optionalTypes = optionalTypes
+	}
+}
+
 // WithPackageComment sets the package comment.
 func WithPackageComment(packageComment string) GeneratorOption {
 	return func(g *Generator) {
@@ -138,6 +545,26 @@ func WithPackageName(packageName string) GeneratorOption {
 	}
 }
 
+// WithPointers sets when pointers are used for strings, numbers, booleans,
+// times, objects, and fixed-length arrays that may be absent or null. Slices,
+// maps, and other types whose zero value is nil never use pointers. Options
+// other than PointersAuto apply the same policy to all of these types and
+// generate ,omitzero tags where they are needed to omit absent values.
+func WithPointers(pointers PointersType) GeneratorOption {
+	return func(g *Generator) {
+		g.pointers = pointers
+	}
+}
+
+// WithPolymorphicTypes sets whether properties observed as both strings and
+// numbers, or as both single values and arrays, should use the generated
+// StringOrNumber and OneOrMany helper types rather than any.
+func WithPolymorphicTypes(polymorphicTypes bool) GeneratorOption {
+	return func(g *Generator) {
+		g.polymorphicTypes = polymorphicTypes
+	}
+}
+
 // WithRenames sets the renames.
 func WithRenames(renames map[string]string) GeneratorOption {
 	return func(g *Generator) {
@@ -160,6 +587,25 @@ func WithStringTags(stringTags bool) GeneratorOption {
 	}
 }
 
+// WithStringFormats sets the string formats to detect, for example
+// DefaultStringFormats(). Strings that always match the same format are
+// generated as the format's Go type or, if it does not have one, as strings
+// with a comment naming the format.
+func WithStringFormats(stringFormats ...StringFormat) GeneratorOption {
+	return func(g *Generator) {
+		g.stringFormats = stringFormats
+	}
+}
+
+// WithStringEnums sets the maximum number of distinct values of a string
+// property for it to be generated as an enum type with constants. Zero disables
+// string enums.
+func WithStringEnums(maxStringEnumValues int) GeneratorOption {
+	return func(g *Generator) {
+		g.maxStringEnumValues = maxStringEnumValues
+	}
+}
+
 // WithStructTagName sets the struct tag name.
 func WithStructTagName(structTagName string) GeneratorOption {
 	return func(g *Generator) {
@@ -190,6 +636,17 @@ func WithImports(imports ...string) GeneratorOption {
 	}
 }
 
+// WithTimeLayouts sets additional time layouts, as used by time.Parse, to try
+// when observing strings. Strings that always match the same layout are
+// generated as a helper type that marshals and unmarshals with that layout.
+// Earlier layouts take precedence. RFC 3339 strings are always generated as
+// time.Time.
+func WithTimeLayouts(timeLayouts ...string) GeneratorOption {
+	return func(g *Generator) {
+		g.timeLayouts = timeLayouts
+	}
+}
+
 // WithTypeComment sets the type comment.
 func WithTypeComment(typeComment string) GeneratorOption {
 	return func(g *Generator) {
@@ -204,6 +661,24 @@ func WithTypeName(typeName string) GeneratorOption {
 	}
 }
 
+// WithTypedMapKeys sets whether maps should have integer, time.Time, or UUID
+// keys when all observed keys can be unmarshalled into these types.
+func WithTypedMapKeys(typedMapKeys bool) GeneratorOption {
+	return func(g *Generator) {
+		g.typedMapKeys = typedMapKeys
+	}
+}
+
+// WithUnparsableProperties sets how properties whose names cannot be used as
+// encoding/json struct tag names are handled. UnparsablePropertiesMethods does
+// not apply to discriminated unions or recursive types, whose unparsable
+// properties are handled as with UnparsablePropertiesComment.
+func WithUnparsableProperties(unparsableProperties UnparsablePropertiesType) GeneratorOption {
+	return func(g *Generator) {
+		g.unparsableProperties = unparsableProperties
+	}
+}
+
 // WithUseJSONNumber sets whether to use json.Number when both int and float64s
 // are observed for the same property.
 func WithUseJSONNumber(useJSONNumber bool) GeneratorOption {
@@ -216,10 +691,18 @@ func WithUseJSONNumber(useJSONNumber bool) GeneratorOption {
 func NewGenerator(options ...GeneratorOption) *Generator {
 	g := &Generator{
 		abbreviations:            maps.Clone(defaultAbbreviations),
+		decimalImport:            "encoding/json",
+		decimalNames:             []string{"amount", "balance", "cost", "fee", "price", "total"},
+		decimalType:              "json.Number",
+		epochTimeSuffixes:        []string{"_at", "_time", "timestamp", "ts"},
 		exportRenames:            make(map[string]string),
 		goFormat:                 true,
 		imports:                  make(map[string]struct{}),
+		intEnumNames:             make(map[string]map[int64]string),
 		intType:                  "int",
+		mapOverrides:             make(map[string]bool),
+		mapThreshold:             8,
+		numbersOverrides:         make(map[string]NumbersType),
 		omitEmptyTags:            OmitEmptyTagsAuto,
 		omitZeroTags:             OmitZeroTagsNever,
 		packageName:              "main",
@@ -243,6 +726,9 @@ func NewGenerator(options ...GeneratorOption) *Generator {
 
 // Generate returns the Go source code for the observed values.
 func (g *Generator) Generate() ([]byte, error) {
+	if !isValidTypeName(g.typeName) {
+		return nil, fmt.Errorf("%s: invalid type name", g.typeName)
+	}
 	buffer := &bytes.Buffer{}
 	buffer.Grow(65536)
 	if g.fileHeader != "" {
@@ -252,38 +738,126 @@ func (g *Generator) Generate() ([]byte, error) {
 		fmt.Fprintf(buffer, "// %s\n", g.packageComment)
 	}
 	fmt.Fprintf(buffer, "package %s\n", g.packageName)
-	imports := maps.Clone(g.imports)
-	goType := g.value.goType(0, &generateOptions{
+	// Helper types with fixed names, like UUID, are only declared when they
+	// are needed, so a type declared earlier may already use their names. If
+	// so, generate the types again with these names reserved for the helper
+	// types.
+	reservedTypeNames := make(map[string]struct{})
+	var options *generateOptions
+	var goType goType
+	for {
+		options = g.generateOptions(reservedTypeNames)
+		goType = g.value.goType(valuePath{g.typeName}, 0, options)
+		n := len(reservedTypeNames)
+		maps.Copy(reservedTypeNames, options.collidingHelperNames)
+		if len(reservedTypeNames) == n {
+			break
+		}
+	}
+	if _, ok := options.helperDecls[g.typeName]; ok {
+		return nil, fmt.Errorf("%s: type name is used by a helper type", g.typeName)
+	}
+	declsBuffer := &bytes.Buffer{}
+	if g.typeComment != "" {
+		fmt.Fprintf(declsBuffer, "// %s\n", g.typeComment)
+	}
+	fmt.Fprintf(declsBuffer, "type %s %s\n", g.typeName, goType.typeStr)
+	for _, name := range slices.Sorted(maps.Keys(options.typeDecls)) {
+		fmt.Fprintf(declsBuffer, "\ntype %s %s\n", name, options.typeDecls[name])
+	}
+	for _, name := range slices.Sorted(maps.Keys(options.helperDecls)) {
+		// Reserved names, like the names of enum constants, have empty
+		// declarations.
+		if helperDecl := options.helperDecls[name]; helperDecl != "" {
+			fmt.Fprintf(declsBuffer, "\n%s\n", helperDecl)
+		}
+	}
+	if len(options.imports) > 0 {
+		fmt.Fprintf(buffer, "import (\n")
+		for _, _import := range slices.Sorted(maps.Keys(options.imports)) {
+			fmt.Fprintf(buffer, "\"%s\"\n", _import)
+		}
+		fmt.Fprintf(buffer, ")\n")
+	}
+	buffer.Write(declsBuffer.Bytes())
+	if !g.goFormat {
+		return buffer.Bytes(), nil
+	}
+	return format.Source(buffer.Bytes())
+}
+
+// generateOptions returns new options for generating the types of the observed
+// values, with reservedTypeNames reserved for helper types.
+func (g *Generator) generateOptions(reservedTypeNames map[string]struct{}) *generateOptions {
+	options := &generateOptions{
+		decimalImport:            g.decimalImport,
+		decimalNames:             g.decimalNames,
+		decimalType:              g.decimalType,
+		deduplicateTypes:         g.deduplicateTypes,
+		detectDecimals:           g.detectDecimals,
+		detectEpochTimes:         g.detectEpochTimes,
+		detectMaps:               g.detectMaps,
+		detectRecursiveTypes:     g.detectRecursiveTypes,
+		detectTuples:             g.detectTuples,
+		detectUnions:             g.detectUnions,
+		discriminators:           g.discriminators,
+		enumValidation:           g.enumValidation,
+		epochTimeSuffixes:        g.epochTimeSuffixes,
 		exportNameFunc:           g.exportNameFunc,
-		imports:                  imports,
+		extractNestedTypes:       g.extractNestedTypes || g.deduplicateTypes != DeduplicateTypesNever,
+		imports:                  maps.Clone(g.imports),
+		intEnumNames:             g.intEnumNames,
 		intType:                  g.intType,
+		intTypes:                 g.intTypes,
+		largeInts:                g.largeInts,
+		mapOverrides:             g.mapOverrides,
+		mapThreshold:             g.mapThreshold,
+		nullableTypes:            g.nullableTypes,
+		numbers:                  g.numbers,
+		numbersOverrides:         g.numbersOverrides,
 		omitEmptyTags:            g.omitEmptyTags,
 		omitZeroTags:             g.omitZeroTags,
+		optionalTypes:            g.optionalTypes,
+		pointers:                 g.pointers,
+		polymorphicTypes:         g.polymorphicTypes,
+		reservedTypeNames:        reservedTypeNames,
 		skipUnparsableProperties: g.skipUnparsableProperties,
+		stringFormats:            g.stringFormats,
 		stringTags:               g.stringTags,
 		structTagNames:           g.structTagNames,
+		timeLayouts:              g.timeLayouts,
+		typedMapKeys:             g.typedMapKeys,
+		unparsableProperties:     g.unparsableProperties,
 		useJSONNumber:            g.useJSONNumber,
-	})
-	if len(imports) > 0 {
-		fmt.Fprintf(buffer, "import (\n")
-		for _, _import := range slices.Sorted(maps.Keys(imports)) {
-			fmt.Fprintf(buffer, "\"%s\"\n", _import)
-		}
-		fmt.Fprintf(buffer, ")\n")
 	}
-	if g.typeComment != "" {
-		fmt.Fprintf(buffer, "// %s\n", g.typeComment)
+	if g.deduplicateTypes == DeduplicateTypesCompatible {
+		options.mergeCompatibleObjects(g.value, valuePath{g.typeName})
 	}
-	fmt.Fprintf(buffer, "type %s %s\n", g.typeName, goType.typeStr)
-	if !g.goFormat {
-		return buffer.Bytes(), nil
+	if g.detectRecursiveTypes {
+		options.findRecursiveTypes(g.value, valuePath{g.typeName})
 	}
-	return format.Source(buffer.Bytes())
+	return options
 }
 
 // ObserveValue observes value.
 func (g *Generator) ObserveValue(value any) {
-	g.value = g.value.observe(value)
+	g.value = g.value.observe(value, &observeOptions{
+		detectBase64:           g.detectBase64,
+		detectBase64Variants:   g.detectBase64Variants,
+		detectDurations:        g.detectDurations,
+		detectISO8601Durations: g.detectISO8601Durations,
+		detectUnions:           g.detectUnions,
+		discriminators:         g.discriminators,
+		largeInts:              g.largeInts != LargeIntsFloat64,
+		maxIntEnumValues:       g.maxIntEnumValues,
+		maxStringEnumValues:    g.maxStringEnumValues,
+		stringFormats:          g.stringFormats,
+		timeLayouts:            g.timeLayouts,
+		trackArrayLengths:      g.detectTuples,
+		trackFractionDigits:    g.detectDecimals,
+		trackIntRange:          g.detectEpochTimes || g.intTypes != IntTypesFixed || g.largeInts != LargeIntsFloat64,
+		trackIntegralFloats:    g.numbers != NumbersAsObserved || len(g.numbersOverrides) > 0,
+	})
 }
 
 // ObserveJSONReader observes JSON values from r.
@@ -340,8 +914,3 @@ func (g *Generator) ObserveYAMLFile(filename string) error {
 	defer file.Close()
 	return g.ObserveYAMLReader(file)
 }
-
-// isUnparsableProperty returns true if key cannot be parsed by encoding/json.
-func isUnparsableProperty(key string) bool {
-	return strings.ContainsAny(key, ` ",`)
-}
@@ -3,10 +3,15 @@ package jsonstruct
 import (
 	"bytes"
 	"errors"
+	"fmt"
 	"io/fs"
+	"math"
 	"os"
+	"os/exec"
+	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/alecthomas/assert/v2"
 )
@@ -745,6 +750,127 @@ func TestGoType(t *testing.T) {
 			},
 			expectedGoTypeStr: "*string",
 		},
+		{
+			name: "int_range",
+			values: []any{
+				3,
+				-1,
+				2,
+			},
+			expectedValue: &value{
+				observations: 3,
+				ints:         3,
+				minInt:       -1,
+				maxInt:       3,
+			},
+			generatorOptions: []GeneratorOption{
+				WithDetectEpochTimes(true),
+			},
+			expectedGoTypeStr: "int",
+		},
+		{
+			name: "large_uint",
+			values: []any{
+				uint64(math.MaxUint64),
+				uint64(1),
+			},
+			expectedValue: &value{
+				observations: 2,
+				ints:         2,
+				minInt:       1,
+				maxInt:       math.MaxInt64,
+				largeUints:   1,
+			},
+			generatorOptions: []GeneratorOption{
+				WithIntTypes(IntTypesExact),
+			},
+			expectedGoTypeStr: "uint64",
+		},
+		{
+			name: "large_uint_negative",
+			values: []any{
+				uint64(math.MaxUint64),
+				-1,
+			},
+			expectedValue: &value{
+				observations: 2,
+				ints:         2,
+				largeUints:   1,
+			},
+			expectedGoTypeStr: "float64",
+		},
+		{
+			name: "large_uint_default",
+			values: []any{
+				uint64(math.MaxUint64),
+			},
+			expectedValue: &value{
+				observations: 1,
+				ints:         1,
+				largeUints:   1,
+			},
+			expectedGoTypeStr: "float64",
+		},
+		{
+			name: "large_uint_negative_json_number",
+			values: []any{
+				uint64(math.MaxUint64),
+				-1,
+			},
+			expectedValue: &value{
+				observations: 2,
+				ints:         2,
+				minInt:       -1,
+				maxInt:       math.MaxInt64,
+				largeUints:   1,
+				unsafeInts:   1,
+			},
+			generatorOptions: []GeneratorOption{
+				WithLargeInts(LargeIntsJSONNumber),
+			},
+			expectedGoTypeStr: "json.Number",
+			expectedImports: map[string]struct{}{
+				"encoding/json": {},
+			},
+		},
+		{
+			name: "string_enum",
+			values: []any{
+				"a",
+				"b",
+				"a",
+				"b",
+			},
+			expectedValue: &value{
+				observations: 4,
+				strings:      4,
+				stringValues: map[string]int{
+					"a": 2,
+					"b": 2,
+				},
+			},
+			generatorOptions: []GeneratorOption{
+				WithStringEnums(2),
+			},
+			expectedGoTypeStr: "string",
+		},
+		{
+			name: "string_enum_too_many_values",
+			values: []any{
+				"a",
+				"b",
+				"c",
+			},
+			expectedValue: &value{
+				observations:        3,
+				strings:             3,
+				tooManyStringValues: true,
+			},
+			generatorOptions: []GeneratorOption{
+				WithStringEnums(2),
+			},
+			expectedGoTypeStr: "string",
+		},
 		{
 			name: "time",
 			values: []any{
@@ -953,6 +1079,8 @@ func TestGoType(t *testing.T) {
 				exportNameFunc:           generator.exportNameFunc,
 				imports:                  make(map[string]struct{}),
 				intType:                  generator.intType,
+				intTypes:                 generator.intTypes,
+				largeInts:                generator.largeInts,
 				omitEmptyTags:            generator.omitEmptyTags,
 				omitZeroTags:             generator.omitZeroTags,
 				skipUnparsableProperties: generator.skipUnparsableProperties,
@@ -960,7 +1088,7 @@ func TestGoType(t *testing.T) {
 				structTagNames:           generator.structTagNames,
 				useJSONNumber:            generator.useJSONNumber,
 			}
-			goType := generator.value.goType(len(tc.values), options)
+			goType := generator.value.goType(valuePath{generator.typeName}, len(tc.values), options)
 			assert.Equal(t, tc.expectedGoTypeStr, goType.typeStr)
 			if len(tc.expectedImports) == 0 {
 				assert.Equal(t, 0, len(options.imports))
@@ -1349,136 +1477,2618 @@ func TestObserveJSONGoCode(t *testing.T) {
 				"}\n",
 		},
 		{
-			name: "omitzero_auto",
-			json: `{` +
-				`"nonZeroBool":true,"zeroBool":false,` +
-				`"nonZeroInt":1,"zeroInt":0,` +
-				`"nonZeroString":"s","zeroString":"",` +
-				`"nonZeroTime":"2025-01-01T00:00:00Z","zeroTime":"0001-01-01T00:00:00Z"` +
-				`}`,
+			name: "extract_nested_types",
+			json: `{"billing_address":{"city":"Zurich"},"items":[{"id":1,"tags":[{"name":"a"}]}],"user":{"billing_address":{"zip":"8000"}}}`,
 			generatorOptions: []GeneratorOption{
-				WithOmitZeroTags(OmitZeroTagsAuto),
+				WithExtractNestedTypes(true),
 			},
 			expectedGoCodeStr: "" +
 				"package main\n" +
 				"\n" +
-				"import (\n" +
-				"\t\"time\"\n" +
-				")\n" +
+				"type T struct {\n" +
+				"\tBillingAddress BillingAddress `json:\"billing_address\"`\n" +
+				"\tItems          []Item         `json:\"items\"`\n" +
+				"\tUser           User           `json:\"user\"`\n" +
+				"}\n" +
+				"\n" +
+				"type BillingAddress struct {\n" +
+				"\tCity string `json:\"city\"`\n" +
+				"}\n" +
+				"\n" +
+				"type Item struct {\n" +
+				"\tID   int   `json:\"id\"`\n" +
+				"\tTags []Tag `json:\"tags\"`\n" +
+				"}\n" +
+				"\n" +
+				"type Tag struct {\n" +
+				"\tName string `json:\"name\"`\n" +
+				"}\n" +
+				"\n" +
+				"type User struct {\n" +
+				"\tBillingAddress UserBillingAddress `json:\"billing_address\"`\n" +
+				"}\n" +
+				"\n" +
+				"type UserBillingAddress struct {\n" +
+				"\tZip string `json:\"zip\"`\n" +
+				"}\n",
+		},
+		{
+			name: "extract_nested_types_optional",
+			json: `{"data":[{"meta":{"ok":true}},{"meta":null}]}`,
+			generatorOptions: []GeneratorOption{
+				WithExtractNestedTypes(true),
+				WithTypeName("Response"),
+			},
+			expectedGoCodeStr: "" +
+				"package main\n" +
+				"\n" +
+				"type Response struct {\n" +
+				"\tData []DataElement `json:\"data\"`\n" +
+				"}\n" +
+				"\n" +
+				"type DataElement struct {\n" +
+				"\tMeta *Meta `json:\"meta\"`\n" +
+				"}\n" +
+				"\n" +
+				"type Meta struct {\n" +
+				"\tOk bool `json:\"ok\"`\n" +
+				"}\n",
+		},
+		{
+			name: "deduplicate_types_identical",
+			json: `{"created_by":{"id":1,"name":"a"},"owner":{"id":3},"updated_by":{"id":2,"name":"b"}}`,
+			generatorOptions: []GeneratorOption{
+				WithDeduplicateTypes(DeduplicateTypesIdentical),
+			},
+			expectedGoCodeStr: "" +
+				"package main\n" +
 				"\n" +
 				"type T struct {\n" +
-				"\tNonZeroBool   bool      `json:\"nonZeroBool,omitzero\"`\n" +
-				"\tNonZeroInt    int       `json:\"nonZeroInt,omitzero\"`\n" +
-				"\tNonZeroString string    `json:\"nonZeroString,omitzero\"`\n" +
-				"\tNonZeroTime   time.Time `json:\"nonZeroTime,omitzero\"`\n" +
-				"\tZeroBool      bool      `json:\"zeroBool\"`\n" +
-				"\tZeroInt       int       `json:\"zeroInt\"`\n" +
-				"\tZeroString    string    `json:\"zeroString\"`\n" +
-				"\tZeroTime      time.Time `json:\"zeroTime\"`\n" +
+				"\tCreatedBy CreatedBy `json:\"created_by\"`\n" +
+				"\tOwner     Owner     `json:\"owner\"`\n" +
+				"\tUpdatedBy CreatedBy `json:\"updated_by\"`\n" +
+				"}\n" +
+				"\n" +
+				"type CreatedBy struct {\n" +
+				"\tID   int    `json:\"id\"`\n" +
+				"\tName string `json:\"name\"`\n" +
+				"}\n" +
+				"\n" +
+				"type Owner struct {\n" +
+				"\tID int `json:\"id\"`\n" +
 				"}\n",
 		},
 		{
-			name: "omitzero_always",
-			json: `{"zeroBool":false}`,
+			name: "deduplicate_types_compatible",
+			json: `{"created_by":{"email":"a@example.com","id":1,"name":"a"},"owner":{"id":3,"name":"c"},"tags":{"id":"x"},"updated_by":{"id":2,"name":"b"}}`,
 			generatorOptions: []GeneratorOption{
-				WithOmitZeroTags(OmitZeroTagsAlways),
+				WithDeduplicateTypes(DeduplicateTypesCompatible),
 			},
 			expectedGoCodeStr: "" +
 				"package main\n" +
 				"\n" +
 				"type T struct {\n" +
-				"\tZeroBool bool `json:\"zeroBool,omitzero\"`\n" +
+				"\tCreatedBy CreatedBy `json:\"created_by\"`\n" +
+				"\tOwner     CreatedBy `json:\"owner\"`\n" +
+				"\tTags      Tags      `json:\"tags\"`\n" +
+				"\tUpdatedBy CreatedBy `json:\"updated_by\"`\n" +
+				"}\n" +
+				"\n" +
+				"type CreatedBy struct {\n" +
+				"\tEmail string `json:\"email,omitempty\"`\n" +
+				"\tID    int    `json:\"id\"`\n" +
+				"\tName  string `json:\"name\"`\n" +
+				"}\n" +
+				"\n" +
+				"type Tags struct {\n" +
+				"\tID string `json:\"id\"`\n" +
 				"}\n",
 		},
-	} {
-		t.Run(tc.name, func(t *testing.T) {
-			if tc.skip != "" {
-				t.Skip(tc.skip)
-			}
-			generator := NewGenerator(tc.generatorOptions...)
-			err := generator.ObserveJSONReader(bytes.NewBufferString(tc.json))
-			if tc.wantErr {
-				assert.Error(t, err)
-				return
-			}
-			assert.NoError(t, err)
-			goCode, err := generator.Generate()
-			assert.NoError(t, err)
-			assert.Equal(t, tc.expectedGoCodeStr, string(goCode))
-		})
-	}
-}
-
-func TestObserveYAMLGoCode(t *testing.T) {
-	for _, tc := range []struct {
-		skip              string
-		name              string
-		yaml              string
-		wantErr           bool
-		generatorOptions  []GeneratorOption
-		expectedGoCodeStr string
-	}{
 		{
-			name: "empty",
-			yaml: "",
+			name: "detect_maps_dynamic_keys",
+			json: `{"users":{"a1b2c3":{"name":"a"},"d4e5f6":{"name":"b","admin":true}}}`,
+			generatorOptions: []GeneratorOption{
+				WithDetectMaps(true),
+			},
 			expectedGoCodeStr: "" +
 				"package main\n" +
 				"\n" +
-				"type T any\n",
+				"type T struct {\n" +
+				"\tUsers map[string]struct {\n" +
+				"\t\tAdmin bool   `json:\"admin,omitempty\"`\n" +
+				"\t\tName  string `json:\"name\"`\n" +
+				"\t} `json:\"users\"`\n" +
+				"}\n",
 		},
 		{
-			name:    "error",
-			yaml:    "\"",
-			wantErr: true,
+			name: "detect_maps_threshold",
+			json: "" +
+				`{"scores":{"alice":1,"bob":2}}` +
+				`{"scores":{"carol":3,"dave":4}}`,
+			generatorOptions: []GeneratorOption{
+				WithDetectMaps(true),
+				WithMapThreshold(4),
+			},
+			expectedGoCodeStr: "" +
+				"package main\n" +
+				"\n" +
+				"type T struct {\n" +
+				"\tScores map[string]int `json:\"scores\"`\n" +
+				"}\n",
 		},
 		{
-			name: "bool",
-			yaml: "" +
-				`true`,
+			name: "detect_maps_mixed_values",
+			json: `{"1":"a","2":true}`,
+			generatorOptions: []GeneratorOption{
+				WithDetectMaps(true),
+			},
 			expectedGoCodeStr: "" +
 				"package main\n" +
 				"\n" +
-				"type T bool\n",
+				"type T struct {\n" +
+				"\tX1 string `json:\"1\"`\n" +
+				"\tX2 bool   `json:\"2\"`\n" +
+				"}\n",
 		},
 		{
-			name: "strings",
-			yaml: "---\n" +
-				"\"a\"\n" +
-				"---\n" +
-				"\"b\"\n",
+			name: "map_overrides",
+			json: `{"labels":{"app":"web","tier":"frontend"},"names":{"first name":"a","last":"b"},"spec":{"1":"a","2":"b"}}`,
+			generatorOptions: []GeneratorOption{
+				WithDetectMaps(true),
+				WithMapOverrides(map[string]bool{
+					"T.labels": true,
+					"T.names":  false,
+					"T.spec":   false,
+				}),
+			},
 			expectedGoCodeStr: "" +
 				"package main\n" +
 				"\n" +
-				"type T string\n",
+				"type T struct {\n" +
+				"\tLabels map[string]string `json:\"labels\"`\n" +
+				"\tNames  struct {\n" +
+				"\t\tLast string `json:\"last\"`\n" +
+				"\t\t// \"first name\" cannot be unmarshalled into a struct field by encoding/json.\n" +
+				"\t} `json:\"names\"`\n" +
+				"\tSpec struct {\n" +
+				"\t\tX1 string `json:\"1\"`\n" +
+				"\t\tX2 string `json:\"2\"`\n" +
+				"\t} `json:\"spec\"`\n" +
+				"}\n",
 		},
 		{
-			name: "object",
-			yaml: "int: 0\n",
+			name: "detect_maps_dotted_keys",
+			json: `{"config":{"log.level":"debug","spring.datasource.url":"jdbc:h2:mem:test"},"hosts":{"db.example.com":"10.0.0.1","web.example.com":"10.0.0.2"}}`,
 			generatorOptions: []GeneratorOption{
-				WithStructTagName("yaml"),
+				WithDetectMaps(true),
 			},
 			expectedGoCodeStr: "" +
 				"package main\n" +
 				"\n" +
 				"type T struct {\n" +
-				"\tInt int `yaml:\"int\"`\n" +
+				"\tConfig struct {\n" +
+				"\t\tLog_Level             string `json:\"log.level\"`\n" +
+				"\t\tSpring_Datasource_URL string `json:\"spring.datasource.url\"`\n" +
+				"\t} `json:\"config\"`\n" +
+				"\tHosts map[string]string `json:\"hosts\"`\n" +
 				"}\n",
 		},
-	} {
-		t.Run(tc.name, func(t *testing.T) {
-			if tc.skip != "" {
-				t.Skip(tc.skip)
-			}
-			generator := NewGenerator(tc.generatorOptions...)
-			err := generator.ObserveYAMLReader(bytes.NewBufferString(tc.yaml))
-			if tc.wantErr {
-				assert.Error(t, err)
-				return
-			}
-			assert.NoError(t, err)
-			goCode, err := generator.Generate()
-			assert.NoError(t, err)
-			assert.Equal(t, tc.expectedGoCodeStr, string(goCode))
+		{
+			name: "typed_map_keys",
+			json: `{"byID":{"1":"a","23":"b"},"byTime":{"2024-01-31T00:00:00Z":1,"2024-02-01T00:00:00Z":2},"byUUID":{"f47ac10b-58cc-4372-a567-0e02b2c3d479":true,"0e02b2c3-58cc-4372-a567-f47ac10bd479":false},"padded":{"01":1,"02":2}}`,
+			generatorOptions: []GeneratorOption{
+				WithDetectMaps(true),
+				WithTypedMapKeys(true),
+			},
+			expectedGoCodeStr: "" +
+				"package main\n" +
+				"\n" +
+				"import (\n" +
+				"\t\"time\"\n" +
+				")\n" +
+				"\n" +
+				"type T struct {\n" +
+				"\tByID   map[int64]string  `json:\"byID\"`\n" +
+				"\tByTime map[time.Time]int `json:\"byTime\"`\n" +
+				"\tByUUID map[UUID]bool     `json:\"byUUID\"`\n" +
+				"\tPadded map[string]int    `json:\"padded\"`\n" +
+				"}\n" +
+				"\n" +
+				"// A UUID is a UUID in its canonical string representation.\n" +
+				"type UUID string\n",
+		},
+		{
+			name: "typed_map_keys_helper_collision",
+			json: `{"uuid":{"x":1},"z":{"f47ac10b-58cc-4372-a567-0e02b2c3d479":1,"0e02b2c3-58cc-4372-a567-f47ac10bd479":2}}`,
+			generatorOptions: []GeneratorOption{
+				WithDetectMaps(true),
+				WithExtractNestedTypes(true),
+				WithTypedMapKeys(true),
+			},
+			expectedGoCodeStr: "" +
+				"package main\n" +
+				"\n" +
+				"type T struct {\n" +
+				"\tUUID TUUID        `json:\"uuid\"`\n" +
+				"\tZ    map[UUID]int `json:\"z\"`\n" +
+				"}\n" +
+				"\n" +
+				"type TUUID struct {\n" +
+				"\tX int `json:\"x\"`\n" +
+				"}\n" +
+				"\n" +
+				"// A UUID is a UUID in its canonical string representation.\n" +
+				"type UUID string\n",
+		},
+		{
+			name: "detect_unions",
+			json: "" +
+				`{"type":"created","id":1,"name":"a"}` +
+				`{"type":"created","id":2,"name":"b"}` +
+				`{"type":"deleted","id":3,"reason":"x"}` +
+				`{"type":"deleted","id":4,"reason":"y"}`,
+			generatorOptions: []GeneratorOption{
+				WithDetectUnions(true),
+				WithTypeName("Event"),
+			},
+			expectedGoCodeStr: "" +
+				"package main\n" +
+				"\n" +
+				"import (\n" +
+				"\t\"encoding/json\"\n" +
+				"\t\"fmt\"\n" +
+				")\n" +
+				"\n" +
+				"type Event struct {\n" +
+				"\tValue EventValue\n" +
+				"}\n" +
+				"\n" +
+				"// UnmarshalJSON implements encoding/json.Unmarshaler.\n" +
+				"func (v *Event) UnmarshalJSON(data []byte) error {\n" +
+				"\tvar discriminator struct {\n" +
+				"\t\tValue string `json:\"type\"`\n" +
+				"\t}\n" +
+				"\tif err := json.Unmarshal(data, &discriminator); err != nil {\n" +
+				"\t\treturn err\n" +
+				"\t}\n" +
+				"\tswitch discriminator.Value {\n" +
+				"\tcase \"created\":\n" +
+				"\t\tvar value EventCreated\n" +
+				"\t\tif err := json.Unmarshal(data, &value); err != nil {\n" +
+				"\t\t\treturn err\n" +
+				"\t\t}\n" +
+				"\t\tv.Value = value\n" +
+				"\tcase \"deleted\":\n" +
+				"\t\tvar value EventDeleted\n" +
+				"\t\tif err := json.Unmarshal(data, &value); err != nil {\n" +
+				"\t\t\treturn err\n" +
+				"\t\t}\n" +
+				"\t\tv.Value = value\n" +
+				"\tdefault:\n" +
+				"\t\treturn fmt.Errorf(\"%q: unknown type\", discriminator.Value)\n" +
+				"\t}\n" +
+				"\treturn nil\n" +
+				"}\n" +
+				"\n" +
+				"// MarshalJSON implements encoding/json.Marshaler.\n" +
+				"func (v Event) MarshalJSON() ([]byte, error) {\n" +
+				"\treturn json.Marshal(v.Value)\n" +
+				"}\n" +
+				"\n" +
+				"type EventCreated struct {\n" +
+				"\tID   int    `json:\"id\"`\n" +
+				"\tName string `json:\"name\"`\n" +
+				"\tType string `json:\"type\"`\n" +
+				"}\n" +
+				"\n" +
+				"func (EventCreated) isEventValue() {}\n" +
+				"\n" +
+				"type EventDeleted struct {\n" +
+				"\tID     int    `json:\"id\"`\n" +
+				"\tReason string `json:\"reason\"`\n" +
+				"\tType   string `json:\"type\"`\n" +
+				"}\n" +
+				"\n" +
+				"func (EventDeleted) isEventValue() {}\n" +
+				"\n" +
+				"// EventValue is one of EventCreated or EventDeleted, depending on the value of \"type\".\n" +
+				"type EventValue interface {\n" +
+				"\tisEventValue()\n" +
+				"}\n",
+		},
+		{
+			name: "discriminators",
+			json: "" +
+				`{"shape":{"kind":"circle","radius":1}}` +
+				`{"shape":{"kind":"rect","width":2}}` +
+				`{"shape":null}`,
+			generatorOptions: []GeneratorOption{
+				WithDiscriminators("kind"),
+			},
+			expectedGoCodeStr: "" +
+				"package main\n" +
+				"\n" +
+				"import (\n" +
+				"\t\"encoding/json\"\n" +
+				"\t\"fmt\"\n" +
+				")\n" +
+				"\n" +
+				"type T struct {\n" +
+				"\tShape *Shape `json:\"shape\"`\n" +
+				"}\n" +
+				"\n" +
+				"type Shape struct {\n" +
+				"\tValue ShapeValue\n" +
+				"}\n" +
+				"\n" +
+				"// UnmarshalJSON implements encoding/json.Unmarshaler.\n" +
+				"func (v *Shape) UnmarshalJSON(data []byte) error {\n" +
+				"\tvar discriminator struct {\n" +
+				"\t\tValue string `json:\"kind\"`\n" +
+				"\t}\n" +
+				"\tif err := json.Unmarshal(data, &discriminator); err != nil {\n" +
+				"\t\treturn err\n" +
+				"\t}\n" +
+				"\tswitch discriminator.Value {\n" +
+				"\tcase \"circle\":\n" +
+				"\t\tvar value ShapeCircle\n" +
+				"\t\tif err := json.Unmarshal(data, &value); err != nil {\n" +
+				"\t\t\treturn err\n" +
+				"\t\t}\n" +
+				"\t\tv.Value = value\n" +
+				"\tcase \"rect\":\n" +
+				"\t\tvar value ShapeRect\n" +
+				"\t\tif err := json.Unmarshal(data, &value); err != nil {\n" +
+				"\t\t\treturn err\n" +
+				"\t\t}\n" +
+				"\t\tv.Value = value\n" +
+				"\tdefault:\n" +
+				"\t\treturn fmt.Errorf(\"%q: unknown kind\", discriminator.Value)\n" +
+				"\t}\n" +
+				"\treturn nil\n" +
+				"}\n" +
+				"\n" +
+				"// MarshalJSON implements encoding/json.Marshaler.\n" +
+				"func (v Shape) MarshalJSON() ([]byte, error) {\n" +
+				"\treturn json.Marshal(v.Value)\n" +
+				"}\n" +
+				"\n" +
+				"type ShapeCircle struct {\n" +
+				"\tKind   string `json:\"kind\"`\n" +
+				"\tRadius int    `json:\"radius\"`\n" +
+				"}\n" +
+				"\n" +
+				"func (ShapeCircle) isShapeValue() {}\n" +
+				"\n" +
+				"type ShapeRect struct {\n" +
+				"\tKind  string `json:\"kind\"`\n" +
+				"\tWidth int    `json:\"width\"`\n" +
+				"}\n" +
+				"\n" +
+				"func (ShapeRect) isShapeValue() {}\n" +
+				"\n" +
+				"// ShapeValue is one of ShapeCircle or ShapeRect, depending on the value of \"kind\".\n" +
+				"type ShapeValue interface {\n" +
+				"\tisShapeValue()\n" +
+				"}\n",
+		},
+		{
+			name: "nested_discriminators",
+			json: "" +
+				`{"type":"a","shape":{"kind":"circle","radius":1}}` +
+				`{"type":"a","shape":{"kind":"rect","width":2}}` +
+				`{"type":"b","reason":"x"}` +
+				`{"type":"b"}`,
+			generatorOptions: []GeneratorOption{
+				WithDiscriminators("type", "kind"),
+			},
+			expectedGoCodeStr: "" +
+				"package main\n" +
+				"\n" +
+				"import (\n" +
+				"\t\"encoding/json\"\n" +
+				"\t\"fmt\"\n" +
+				")\n" +
+				"\n" +
+				"type T struct {\n" +
+				"\tValue TValue\n" +
+				"}\n" +
+				"\n" +
+				"type Shape struct {\n" +
+				"\tValue ShapeValue\n" +
+				"}\n" +
+				"\n" +
+				"// UnmarshalJSON implements encoding/json.Unmarshaler.\n" +
+				"func (v *Shape) UnmarshalJSON(data []byte) error {\n" +
+				"\tvar discriminator struct {\n" +
+				"\t\tValue string `json:\"kind\"`\n" +
+				"\t}\n" +
+				"\tif err := json.Unmarshal(data, &discriminator); err != nil {\n" +
+				"\t\treturn err\n" +
+				"\t}\n" +
+				"\tswitch discriminator.Value {\n" +
+				"\tcase \"circle\":\n" +
+				"\t\tvar value ShapeCircle\n" +
+				"\t\tif err := json.Unmarshal(data, &value); err != nil {\n" +
+				"\t\t\treturn err\n" +
+				"\t\t}\n" +
+				"\t\tv.Value = value\n" +
+				"\tcase \"rect\":\n" +
+				"\t\tvar value ShapeRect\n" +
+				"\t\tif err := json.Unmarshal(data, &value); err != nil {\n" +
+				"\t\t\treturn err\n" +
+				"\t\t}\n" +
+				"\t\tv.Value = value\n" +
+				"\tdefault:\n" +
+				"\t\treturn fmt.Errorf(\"%q: unknown kind\", discriminator.Value)\n" +
+				"\t}\n" +
+				"\treturn nil\n" +
+				"}\n" +
+				"\n" +
+				"// MarshalJSON implements encoding/json.Marshaler.\n" +
+				"func (v Shape) MarshalJSON() ([]byte, error) {\n" +
+				"\treturn json.Marshal(v.Value)\n" +
+				"}\n" +
+				"\n" +
+				"type ShapeCircle struct {\n" +
+				"\tKind   string `json:\"kind\"`\n" +
+				"\tRadius int    `json:\"radius\"`\n" +
+				"}\n" +
+				"\n" +
+				"func (ShapeCircle) isShapeValue() {}\n" +
+				"\n" +
+				"type ShapeRect struct {\n" +
+				"\tKind  string `json:\"kind\"`\n" +
+				"\tWidth int    `json:\"width\"`\n" +
+				"}\n" +
+				"\n" +
+				"func (ShapeRect) isShapeValue() {}\n" +
+				"\n" +
+				"// ShapeValue is one of ShapeCircle or ShapeRect, depending on the value of \"kind\".\n" +
+				"type ShapeValue interface {\n" +
+				"\tisShapeValue()\n" +
+				"}\n" +
+				"\n" +
+				"// UnmarshalJSON implements encoding/json.Unmarshaler.\n" +
+				"func (v *T) UnmarshalJSON(data []byte) error {\n" +
+				"\tvar discriminator struct {\n" +
+				"\t\tValue string `json:\"type\"`\n" +
+				"\t}\n" +
+				"\tif err := json.Unmarshal(data, &discriminator); err != nil {\n" +
+				"\t\treturn err\n" +
+				"\t}\n" +
+				"\tswitch discriminator.Value {\n" +
+				"\tcase \"a\":\n" +
+				"\t\tvar value TA\n" +
+				"\t\tif err := json.Unmarshal(data, &value); err != nil {\n" +
+				"\t\t\treturn err\n" +
+				"\t\t}\n" +
+				"\t\tv.Value = value\n" +
+				"\tcase \"b\":\n" +
+				"\t\tvar value TB\n" +
+				"\t\tif err := json.Unmarshal(data, &value); err != nil {\n" +
+				"\t\t\treturn err\n" +
+				"\t\t}\n" +
+				"\t\tv.Value = value\n" +
+				"\tdefault:\n" +
+				"\t\treturn fmt.Errorf(\"%q: unknown type\", discriminator.Value)\n" +
+				"\t}\n" +
+				"\treturn nil\n" +
+				"}\n" +
+				"\n" +
+				"// MarshalJSON implements encoding/json.Marshaler.\n" +
+				"func (v T) MarshalJSON() ([]byte, error) {\n" +
+				"\treturn json.Marshal(v.Value)\n" +
+				"}\n" +
+				"\n" +
+				"type TA struct {\n" +
+				"\tShape Shape  `json:\"shape\"`\n" +
+				"\tType  string `json:\"type\"`\n" +
+				"}\n" +
+				"\n" +
+				"func (TA) isTValue() {}\n" +
+				"\n" +
+				"type TB struct {\n" +
+				"\tReason string `json:\"reason,omitempty\"`\n" +
+				"\tType   string `json:\"type\"`\n" +
+				"}\n" +
+				"\n" +
+				"func (TB) isTValue() {}\n" +
+				"\n" +
+				"// TValue is one of TA or TB, depending on the value of \"type\".\n" +
+				"type TValue interface {\n" +
+				"\tisTValue()\n" +
+				"}\n",
+		},
+		{
+			name: "discriminators_nested_objects",
+			json: "" +
+				`{"type":"click","payload":{"x":1,"y":2}}` +
+				`{"type":"key","payload":{"key":"a"}}`,
+			generatorOptions: []GeneratorOption{
+				WithDiscriminators("type"),
+			},
+			expectedGoCodeStr: "" +
+				"package main\n" +
+				"\n" +
+				"import (\n" +
+				"\t\"encoding/json\"\n" +
+				"\t\"fmt\"\n" +
+				")\n" +
+				"\n" +
+				"type T struct {\n" +
+				"\tValue TValue\n" +
+				"}\n" +
+				"\n" +
+				"// UnmarshalJSON implements encoding/json.Unmarshaler.\n" +
+				"func (v *T) UnmarshalJSON(data []byte) error {\n" +
+				"\tvar discriminator struct {\n" +
+				"\t\tValue string `json:\"type\"`\n" +
+				"\t}\n" +
+				"\tif err := json.Unmarshal(data, &discriminator); err != nil {\n" +
+				"\t\treturn err\n" +
+				"\t}\n" +
+				"\tswitch discriminator.Value {\n" +
+				"\tcase \"click\":\n" +
+				"\t\tvar value TClick\n" +
+				"\t\tif err := json.Unmarshal(data, &value); err != nil {\n" +
+				"\t\t\treturn err\n" +
+				"\t\t}\n" +
+				"\t\tv.Value = value\n" +
+				"\tcase \"key\":\n" +
+				"\t\tvar value TKey\n" +
+				"\t\tif err := json.Unmarshal(data, &value); err != nil {\n" +
+				"\t\t\treturn err\n" +
+				"\t\t}\n" +
+				"\t\tv.Value = value\n" +
+				"\tdefault:\n" +
+				"\t\treturn fmt.Errorf(\"%q: unknown type\", discriminator.Value)\n" +
+				"\t}\n" +
+				"\treturn nil\n" +
+				"}\n" +
+				"\n" +
+				"// MarshalJSON implements encoding/json.Marshaler.\n" +
+				"func (v T) MarshalJSON() ([]byte, error) {\n" +
+				"\treturn json.Marshal(v.Value)\n" +
+				"}\n" +
+				"\n" +
+				"type TClick struct {\n" +
+				"\tPayload struct {\n" +
+				"\t\tX int `json:\"x\"`\n" +
+				"\t\tY int `json:\"y\"`\n" +
+				"\t} `json:\"payload\"`\n" +
+				"\tType string `json:\"type\"`\n" +
+				"}\n" +
+				"\n" +
+				"func (TClick) isTValue() {}\n" +
+				"\n" +
+				"type TKey struct {\n" +
+				"\tPayload struct {\n" +
+				"\t\tKey string `json:\"key\"`\n" +
+				"\t} `json:\"payload\"`\n" +
+				"\tType string `json:\"type\"`\n" +
+				"}\n" +
+				"\n" +
+				"func (TKey) isTValue() {}\n" +
+				"\n" +
+				"// TValue is one of TClick or TKey, depending on the value of \"type\".\n" +
+				"type TValue interface {\n" +
+				"\tisTValue()\n" +
+				"}\n",
+		},
+		{
+			name: "polymorphic_string_or_number",
+			json: "" +
+				`{"id":"42","n":null}` +
+				`{"id":42,"n":1.5}` +
+				`{"id":43,"n":"1"}`,
+			generatorOptions: []GeneratorOption{
+				WithPolymorphicTypes(true),
+			},
+			expectedGoCodeStr: "" +
+				"package main\n" +
+				"\n" +
+				"import (\n" +
+				"\t\"encoding/json\"\n" +
+				")\n" +
+				"\n" +
+				"type T struct {\n" +
+				"\tID StringOrNumber  `json:\"id\"`\n" +
+				"\tN  *StringOrNumber `json:\"n\"`\n" +
+				"}\n" +
+				"\n" +
+				stringOrNumberHelperDecl + "\n",
+		},
+		{
+			name: "polymorphic_one_or_many",
+			json: "" +
+				`{"owner":{"name":"a"},"tags":"a"}` +
+				`{"owner":[{"name":"b"}],"tags":["b","c"]}`,
+			generatorOptions: []GeneratorOption{
+				WithPolymorphicTypes(true),
+			},
+			expectedGoCodeStr: "" +
+				"package main\n" +
+				"\n" +
+				"import (\n" +
+				"\t\"encoding/json\"\n" +
+				")\n" +
+				"\n" +
+				"type T struct {\n" +
+				"\tOwner OneOrMany[struct {\n" +
+				"\t\tName string `json:\"name\"`\n" +
+				"\t}] `json:\"owner\"`\n" +
+				"\tTags OneOrMany[string] `json:\"tags\"`\n" +
+				"}\n" +
+				"\n" +
+				oneOrManyHelperDecl + "\n",
+		},
+		{
+			name: "polymorphic_one_or_many_null",
+			json: "" +
+				`{"tags":"a"}` +
+				`{"tags":["b"]}` +
+				`{"tags":null}`,
+			generatorOptions: []GeneratorOption{
+				WithPolymorphicTypes(true),
+			},
+			expectedGoCodeStr: "" +
+				"package main\n" +
+				"\n" +
+				"import (\n" +
+				"\t\"encoding/json\"\n" +
+				")\n" +
+				"\n" +
+				"type T struct {\n" +
+				"\tTags OneOrMany[string] `json:\"tags\"`\n" +
+				"}\n" +
+				"\n" +
+				oneOrManyHelperDecl + "\n",
+		},
+		{
+			name: "polymorphic_mixed",
+			json: "" +
+				`{"value":true}` +
+				`{"value":"a"}`,
+			generatorOptions: []GeneratorOption{
+				WithPolymorphicTypes(true),
+			},
+			expectedGoCodeStr: "" +
+				"package main\n" +
+				"\n" +
+				"type T struct {\n" +
+				"\tValue any `json:\"value\"`\n" +
+				"}\n",
+		},
+		{
+			name: "string_enums",
+			json: "" +
+				`{"name":"a","region":"eu-west-1","status":"active"}` +
+				`{"name":"b","region":null,"status":"in-progress"}` +
+				`{"name":"c","region":"us-east-1","status":"active"}` +
+				`{"name":"d","region":"eu-west-1","status":"in-progress"}` +
+				`{"name":"e","region":"us-east-1","status":"active"}`,
+			generatorOptions: []GeneratorOption{
+				WithStringEnums(4),
+			},
+			expectedGoCodeStr: "" +
+				"package main\n" +
+				"\n" +
+				"type T struct {\n" +
+				"\tName   string  `json:\"name\"`\n" +
+				"\tRegion *Region `json:\"region\"`\n" +
+				"\tStatus Status  `json:\"status\"`\n" +
+				"}\n" +
+				"\n" +
+				"type Region string\n" +
+				"\n" +
+				"const (\n" +
+				"\tRegionEuWest1 Region = \"eu-west-1\"\n" +
+				"\tRegionUsEast1 Region = \"us-east-1\"\n" +
+				")\n" +
+				"\n" +
+				"type Status string\n" +
+				"\n" +
+				"const (\n" +
+				"\tStatusActive     Status = \"active\"\n" +
+				"\tStatusInProgress Status = \"in-progress\"\n" +
+				")\n",
+		},
+		{
+			name: "string_enums_const_name_collisions",
+			json: "" +
+				`{"a":{"statusValue":{"x":1}},"status":"value","z":{"statusDone":{"y":1}}}` +
+				`{"a":{"statusValue":{"x":2}},"status":"done","z":{"statusDone":{"y":2}}}` +
+				`{"a":{"statusValue":{"x":1}},"status":"value","z":{"statusDone":{"y":1}}}` +
+				`{"a":{"statusValue":{"x":2}},"status":"done","z":{"statusDone":{"y":2}}}` +
+				`{"a":{"statusValue":{"x":1}},"status":"value","z":{"statusDone":{"y":1}}}`,
+			generatorOptions: []GeneratorOption{
+				WithExtractNestedTypes(true),
+				WithStringEnums(4),
+			},
+			expectedGoCodeStr: "" +
+				"package main\n" +
+				"\n" +
+				"type T struct {\n" +
+				"\tA      A      `json:\"a\"`\n" +
+				"\tStatus Status `json:\"status\"`\n" +
+				"\tZ      Z      `json:\"z\"`\n" +
+				"}\n" +
+				"\n" +
+				"type A struct {\n" +
+				"\tStatusValue StatusValue `json:\"statusValue\"`\n" +
+				"}\n" +
+				"\n" +
+				"type StatusValue struct {\n" +
+				"\tX int `json:\"x\"`\n" +
+				"}\n" +
+				"\n" +
+				"type Z struct {\n" +
+				"\tStatusDone ZStatusDone `json:\"statusDone\"`\n" +
+				"}\n" +
+				"\n" +
+				"type ZStatusDone struct {\n" +
+				"\tY int `json:\"y\"`\n" +
+				"}\n" +
+				"\n" +
+				"type Status string\n" +
+				"\n" +
+				"const (\n" +
+				"\tStatusDone   Status = \"done\"\n" +
+				"\tStatusValue2 Status = \"value\"\n" +
+				")\n",
+		},
+		{
+			name: "string_enums_deduplicate_types",
+			json: "" +
+				`{"a":{"id":1,"status":"on"},"b":{"id":2,"status":"off"}}` +
+				`{"a":{"id":3,"status":"off"},"b":{"id":4,"status":"on"}}`,
+			generatorOptions: []GeneratorOption{
+				WithDeduplicateTypes(DeduplicateTypesCompatible),
+				WithStringEnums(4),
+			},
+			expectedGoCodeStr: "" +
+				"package main\n" +
+				"\n" +
+				"type T struct {\n" +
+				"\tA A `json:\"a\"`\n" +
+				"\tB A `json:\"b\"`\n" +
+				"}\n" +
+				"\n" +
+				"type A struct {\n" +
+				"\tID     int    `json:\"id\"`\n" +
+				"\tStatus Status `json:\"status\"`\n" +
+				"}\n" +
+				"\n" +
+				"type Status string\n" +
+				"\n" +
+				"const (\n" +
+				"\tStatusOff Status = \"off\"\n" +
+				"\tStatusOn  Status = \"on\"\n" +
+				")\n",
+		},
+		{
+			name: "string_enums_validation",
+			json: `"USD" "EUR" "USD" "USD"`,
+			generatorOptions: []GeneratorOption{
+				WithStringEnums(4),
+				WithEnumValidation(true),
+				WithTypeName("Currency"),
+			},
+			expectedGoCodeStr: "" +
+				"package main\n" +
+				"\n" +
+				"import (\n" +
+				"\t\"fmt\"\n" +
+				")\n" +
+				"\n" +
+				"type Currency string\n" +
+				"\n" +
+				"const (\n" +
+				"\tCurrencyEur Currency = \"EUR\"\n" +
+				"\tCurrencyUsd Currency = \"USD\"\n" +
+				")\n" +
+				"\n" +
+				"// IsValid returns true if v is a known Currency.\n" +
+				"func (v Currency) IsValid() bool {\n" +
+				"\tswitch v {\n" +
+				"\tcase CurrencyEur, CurrencyUsd:\n" +
+				"\t\treturn true\n" +
+				"\tdefault:\n" +
+				"\t\treturn false\n" +
+				"\t}\n" +
+				"}\n" +
+				"\n" +
+				"// UnmarshalText implements encoding.TextUnmarshaler.\n" +
+				"func (v *Currency) UnmarshalText(text []byte) error {\n" +
+				"\tvalue := Currency(text)\n" +
+				"\tif !value.IsValid() {\n" +
+				"\t\treturn fmt.Errorf(\"%q: invalid Currency\", text)\n" +
+				"\t}\n" +
+				"\t*v = value\n" +
+				"\treturn nil\n" +
+				"}\n",
+		},
+		{
+			name: "string_enums_too_many_values",
+			json: `"a" "b" "c" "a" "b" "c"`,
+			generatorOptions: []GeneratorOption{
+				WithStringEnums(2),
+			},
+			expectedGoCodeStr: "" +
+				"package main\n" +
+				"\n" +
+				"type T string\n",
+		},
+		{
+			name: "int_enums",
+			json: "" +
+				`{"count":1,"level":-1,"priority":1}` +
+				`{"count":2,"level":0,"priority":2}` +
+				`{"count":3,"level":-1,"priority":1}` +
+				`{"count":4,"level":null,"priority":2}` +
+				`{"count":5,"level":0,"priority":1}`,
+			generatorOptions: []GeneratorOption{
+				WithIntEnums(4),
+				WithIntEnumNames(map[string]map[int64]string{
+					"T.priority": {
+						1: "Low",
+						2: "High",
+					},
+				}),
+			},
+			expectedGoCodeStr: "" +
+				"package main\n" +
+				"\n" +
+				"type T struct {\n" +
+				"\tCount    int      `json:\"count\"`\n" +
+				"\tLevel    *Level   `json:\"level\"`\n" +
+				"\tPriority Priority `json:\"priority\"`\n" +
+				"}\n" +
+				"\n" +
+				"type Level int\n" +
+				"\n" +
+				"const (\n" +
+				"\tLevelMinus1 Level = -1\n" +
+				"\tLevel0      Level = 0\n" +
+				")\n" +
+				"\n" +
+				"type Priority int\n" +
+				"\n" +
+				"const (\n" +
+				"\tPriorityLow  Priority = 1\n" +
+				"\tPriorityHigh Priority = 2\n" +
+				")\n",
+		},
+		{
+			name: "int_enums_validation",
+			json: `200 404 200 404`,
+			generatorOptions: []GeneratorOption{
+				WithIntEnums(4),
+				WithEnumValidation(true),
+				WithTypeName("StatusCode"),
+			},
+			expectedGoCodeStr: "" +
+				"package main\n" +
+				"\n" +
+				"import (\n" +
+				"\t\"encoding/json\"\n" +
+				"\t\"fmt\"\n" +
+				")\n" +
+				"\n" +
+				"type StatusCode int\n" +
+				"\n" +
+				"const (\n" +
+				"\tStatusCode200 StatusCode = 200\n" +
+				"\tStatusCode404 StatusCode = 404\n" +
+				")\n" +
+				"\n" +
+				"// IsValid returns true if v is a known StatusCode.\n" +
+				"func (v StatusCode) IsValid() bool {\n" +
+				"\tswitch v {\n" +
+				"\tcase StatusCode200, StatusCode404:\n" +
+				"\t\treturn true\n" +
+				"\tdefault:\n" +
+				"\t\treturn false\n" +
+				"\t}\n" +
+				"}\n" +
+				"\n" +
+				"// UnmarshalJSON implements encoding/json.Unmarshaler.\n" +
+				"func (v *StatusCode) UnmarshalJSON(data []byte) error {\n" +
+				"\tif string(data) == \"null\" {\n" +
+				"\t\treturn nil\n" +
+				"\t}\n" +
+				"\tvar underlying int\n" +
+				"\tif err := json.Unmarshal(data, &underlying); err != nil {\n" +
+				"\t\treturn err\n" +
+				"\t}\n" +
+				"\tvalue := StatusCode(underlying)\n" +
+				"\tif !value.IsValid() {\n" +
+				"\t\treturn fmt.Errorf(\"%d: invalid StatusCode\", underlying)\n" +
+				"\t}\n" +
+				"\t*v = value\n" +
+				"\treturn nil\n" +
+				"}\n",
+		},
+		{
+			name: "time_layouts",
+			json: "" +
+				`{"date":"2024-01-31","dateTime":"2024-01-31 12:00:00","mixed":"2024-01-31","updated":null}` +
+				`{"date":"2024-02-01","dateTime":"2024-01-31 12:00:01","mixed":"2024-01-31 12:00:00","updated":"2024-02-01 00:00:00"}`,
+			generatorOptions: []GeneratorOption{
+				WithTimeLayouts(time.DateOnly, time.DateTime),
+			},
+			expectedGoCodeStr: "" +
+				"package main\n" +
+				"\n" +
+				"import (\n" +
+				"\t\"encoding/json\"\n" +
+				"\t\"fmt\"\n" +
+				"\t\"time\"\n" +
+				")\n" +
+				"\n" +
+				"type T struct {\n" +
+				"\tDate     Date      `json:\"date\"`\n" +
+				"\tDateTime DateTime  `json:\"dateTime\"`\n" +
+				"\tMixed    string    `json:\"mixed\"`\n" +
+				"\tUpdated  *DateTime `json:\"updated\"`\n" +
+				"}\n" +
+				"\n" +
+				dateHelperDecl + "\n" +
+				"\n" +
+				fmt.Sprintf(timeLayoutHelperDeclFormat, "DateTime", time.DateTime, "A") + "\n",
+		},
+		{
+			name: "time_layouts_rfc1123",
+			json: `{"updated":"Mon, 02 Jan 2006 15:04:05 MST"}`,
+			generatorOptions: []GeneratorOption{
+				WithTimeLayouts(time.RFC1123),
+			},
+			expectedGoCodeStr: "" +
+				"package main\n" +
+				"\n" +
+				"import (\n" +
+				"\t\"encoding/json\"\n" +
+				"\t\"time\"\n" +
+				")\n" +
+				"\n" +
+				"type T struct {\n" +
+				"\tUpdated RFC1123Time `json:\"updated\"`\n" +
+				"}\n" +
+				"\n" +
+				fmt.Sprintf(timeLayoutHelperDeclFormat, "RFC1123Time", time.RFC1123, "An") + "\n",
+		},
+		{
+			name: "detect_base64",
+			json: "" +
+				`{"name":"HelloWorldFooBar","signature":"V2oH6SbDKflMmn6UjW4Pe9Vn","thumbnail":null,"token":"Cr9D4qQx5KHq8Q3kmhPO1Q"}` +
+				`{"name":"GoodbyeWorldFooo","signature":"ULED5lM7zFm7bYYkCyWwKfxy","thumbnail":"ULED5lM7zFm7bYYkCyWwKfxy","token":"VzvLHzHv9JL_dvAFw0xPYg"}`,
+			generatorOptions: []GeneratorOption{
+				WithDetectBase64(true),
+				WithDetectBase64Variants(true),
+			},
+			expectedGoCodeStr: "" +
+				"package main\n" +
+				"\n" +
+				"import (\n" +
+				"\t\"encoding/base64\"\n" +
+				")\n" +
+				"\n" +
+				"type T struct {\n" +
+				"\tName      string       `json:\"name\"`\n" +
+				"\tSignature []byte       `json:\"signature\"`\n" +
+				"\tThumbnail []byte       `json:\"thumbnail\"`\n" +
+				"\tToken     RawURLBase64 `json:\"token\"`\n" +
+				"}\n" +
+				"\n" +
+				fmt.Sprintf(base64HelperDeclFormat, "RawURLBase64", "unpadded URL-safe base64", "RawURLEncoding") + "\n",
+		},
+		{
+			name: "detect_decimals",
+			json: "" +
+				`{"rating":4.5,"tax":1.25,"total":20,"unitPrice":null}` +
+				`{"rating":3.25,"tax":2.50,"total":19.9,"unitPrice":0.5}`,
+			generatorOptions: []GeneratorOption{
+				WithDetectDecimals(true),
+			},
+			expectedGoCodeStr: "" +
+				"package main\n" +
+				"\n" +
+				"import (\n" +
+				"\t\"encoding/json\"\n" +
+				")\n" +
+				"\n" +
+				"type T struct {\n" +
+				"\tRating    float64      `json:\"rating\"`\n" +
+				"\tTax       json.Number  `json:\"tax\"`\n" +
+				"\tTotal     json.Number  `json:\"total\"`\n" +
+				"\tUnitPrice *json.Number `json:\"unitPrice\"`\n" +
+				"}\n",
+		},
+		{
+			name: "detect_decimals_type",
+			json: "" +
+				`{"amount":1}` +
+				`{"amount":1.5}`,
+			generatorOptions: []GeneratorOption{
+				WithDetectDecimals(true),
+				WithDecimalType("decimal.Decimal", "github.com/shopspring/decimal"),
+			},
+			expectedGoCodeStr: "" +
+				"package main\n" +
+				"\n" +
+				"import (\n" +
+				"\t\"github.com/shopspring/decimal\"\n" +
+				")\n" +
+				"\n" +
+				"type T struct {\n" +
+				"\tAmount decimal.Decimal `json:\"amount\"`\n" +
+				"}\n",
+		},
+		{
+			name: "detect_durations",
+			json: "" +
+				`{"interval":"PT5M","timeout":"30s","ttl":null,"zero":"0"}` +
+				`{"interval":"PT1H30M","timeout":"1h30m","ttl":"10m","zero":"0"}`,
+			generatorOptions: []GeneratorOption{
+				WithDetectDurations(true),
+				WithDetectISO8601Durations(true),
+			},
+			expectedGoCodeStr: "" +
+				"package main\n" +
+				"\n" +
+				"import (\n" +
+				"\t\"fmt\"\n" +
+				"\t\"regexp\"\n" +
+				"\t\"strconv\"\n" +
+				"\t\"strings\"\n" +
+				"\t\"time\"\n" +
+				")\n" +
+				"\n" +
+				"type T struct {\n" +
+				"\tInterval ISO8601Duration `json:\"interval\"`\n" +
+				"\tTimeout  Duration        `json:\"timeout\"`\n" +
+				"\tTtl      *Duration       `json:\"ttl\"`\n" +
+				"\tZero     string          `json:\"zero\"`\n" +
+				"}\n" +
+				"\n" +
+				durationHelperDecl + "\n" +
+				"\n" +
+				iso8601DurationHelperDecl + "\n",
+		},
+		{
+			name: "large_ints_json_number",
+			json: "" +
+				`{"big":123456789012345678901234567890,"id":18446744073709551615,"mixed":1.5,"negative":-1,"serial":"18446744073709551615"}` +
+				`{"big":1,"id":1,"mixed":9007199254740993,"negative":18446744073709551615,"serial":"1"}`,
+			generatorOptions: []GeneratorOption{
+				WithLargeInts(LargeIntsJSONNumber),
+				WithStringTags(true),
+			},
+			expectedGoCodeStr: "" +
+				"package main\n" +
+				"\n" +
+				"import (\n" +
+				"\t\"encoding/json\"\n" +
+				")\n" +
+				"\n" +
+				"type T struct {\n" +
+				"\tBig      json.Number `json:\"big\"`\n" +
+				"\tID       uint64      `json:\"id\"`\n" +
+				"\tMixed    json.Number `json:\"mixed\"`\n" +
+				"\tNegative json.Number `json:\"negative\"`\n" +
+				"\tSerial   uint64      `json:\"serial,string\"`\n" +
+				"}\n",
+		},
+		{
+			name: "large_ints_big_int",
+			json: "" +
+				`{"big":123456789012345678901234567890,"nullable":null}` +
+				`{"big":1,"nullable":-123456789012345678901234567890}`,
+			generatorOptions: []GeneratorOption{
+				WithLargeInts(LargeIntsBigInt),
+			},
+			expectedGoCodeStr: "" +
+				"package main\n" +
+				"\n" +
+				"import (\n" +
+				"\t\"math/big\"\n" +
+				")\n" +
+				"\n" +
+				"type T struct {\n" +
+				"\tBig      *big.Int `json:\"big\"`\n" +
+				"\tNullable *big.Int `json:\"nullable\"`\n" +
+				"}\n",
+		},
+		{
+			name: "numbers_integral_floats_as_ints",
+			json: "" +
+				`{"count":1.0,"mixed":1,"ratio":0.5,"score":1}` +
+				`{"count":2,"mixed":2.0,"ratio":1,"score":2.0}`,
+			generatorOptions: []GeneratorOption{
+				WithNumbers(NumbersIntegralFloatsAsInts),
+				WithNumbersOverrides(map[string]NumbersType{
+					"T.score": NumbersAllFloat64,
+				}),
+			},
+			expectedGoCodeStr: "" +
+				"package main\n" +
+				"\n" +
+				"import (\n" +
+				"\t\"encoding/json\"\n" +
+				"\t\"fmt\"\n" +
+				"\t\"strconv\"\n" +
+				")\n" +
+				"\n" +
+				"type T struct {\n" +
+				"\tCount Int     `json:\"count\"`\n" +
+				"\tMixed Int     `json:\"mixed\"`\n" +
+				"\tRatio float64 `json:\"ratio\"`\n" +
+				"\tScore float64 `json:\"score\"`\n" +
+				"}\n" +
+				"\n" +
+				fmt.Sprintf(integralFloatIntHelperDeclFormat, "Int", "int", "An") + "\n",
+		},
+		{
+			name: "numbers_floats_as_float64",
+			json: "" +
+				`{"count":1,"ratio":0.5}` +
+				`{"count":2,"ratio":1}`,
+			generatorOptions: []GeneratorOption{
+				WithNumbers(NumbersFloatsAsFloat64),
+				WithUseJSONNumber(true),
+			},
+			expectedGoCodeStr: "" +
+				"package main\n" +
+				"\n" +
+				"type T struct {\n" +
+				"\tCount int     `json:\"count\"`\n" +
+				"\tRatio float64 `json:\"ratio\"`\n" +
+				"}\n",
+		},
+		{
+			name: "numbers_all_float64",
+			json: "" +
+				`{"count":1,"ratio":0.5}` +
+				`{"count":2,"ratio":1}`,
+			generatorOptions: []GeneratorOption{
+				WithNumbers(NumbersAllFloat64),
+				WithNumbersOverrides(map[string]NumbersType{
+					"T.count": NumbersAsObserved,
+				}),
+			},
+			expectedGoCodeStr: "" +
+				"package main\n" +
+				"\n" +
+				"type T struct {\n" +
+				"\tCount int     `json:\"count\"`\n" +
+				"\tRatio float64 `json:\"ratio\"`\n" +
+				"}\n",
+		},
+		{
+			name: "int_types_exact",
+			json: "" +
+				`{"big":5000000000,"byte":255,"delta":-129,"port":8080,"rgb":[255,0,128]}` +
+				`{"big":1,"byte":0,"delta":1,"port":null,"rgb":[0,0,0]}`,
+			generatorOptions: []GeneratorOption{
+				WithIntTypes(IntTypesExact),
+			},
+			expectedGoCodeStr: "" +
+				"package main\n" +
+				"\n" +
+				"type T struct {\n" +
+				"\tBig   uint64   `json:\"big\"`\n" +
+				"\tByte  uint8    `json:\"byte\"`\n" +
+				"\tDelta int16    `json:\"delta\"`\n" +
+				"\tPort  *uint16  `json:\"port\"`\n" +
+				"\tRgb   []uint16 `json:\"rgb\"`\n" +
+				"}\n",
+		},
+		{
+			name: "int_types_signed",
+			json: "" +
+				`{"big":5000000000,"byte":255,"delta":-129}` +
+				`{"big":1,"byte":0,"delta":1}`,
+			generatorOptions: []GeneratorOption{
+				WithIntTypes(IntTypesSigned),
+			},
+			expectedGoCodeStr: "" +
+				"package main\n" +
+				"\n" +
+				"type T struct {\n" +
+				"\tBig   int64 `json:\"big\"`\n" +
+				"\tByte  int16 `json:\"byte\"`\n" +
+				"\tDelta int16 `json:\"delta\"`\n" +
+				"}\n",
+		},
+		{
+			name: "int_types_widen",
+			json: "" +
+				`{"big":5000000000,"byte":255,"delta":-129}` +
+				`{"big":1,"byte":0,"delta":1}`,
+			generatorOptions: []GeneratorOption{
+				WithIntTypes(IntTypesWiden),
+			},
+			expectedGoCodeStr: "" +
+				"package main\n" +
+				"\n" +
+				"type T struct {\n" +
+				"\tBig   int64 `json:\"big\"`\n" +
+				"\tByte  int   `json:\"byte\"`\n" +
+				"\tDelta int   `json:\"delta\"`\n" +
+				"}\n",
+		},
+		{
+			name: "optional_types",
+			json: "" +
+				`{"id":1,"name":"a","nickname":null}` +
+				`{"id":2,"nickname":"b"}` +
+				`{"id":3}`,
+			generatorOptions: []GeneratorOption{
+				WithOptionalTypes(true),
+			},
+			expectedGoCodeStr: "" +
+				"package main\n" +
+				"\n" +
+				"import (\n" +
+				"\t\"encoding/json\"\n" +
+				")\n" +
+				"\n" +
+				"type T struct {\n" +
+				"\tID       int              `json:\"id\"`\n" +
+				"\tName     string           `json:\"name,omitempty\"`\n" +
+				"\tNickname Optional[string] `json:\"nickname,omitzero\"`\n" +
+				"}\n" +
+				"\n" +
+				optionalHelperDecl + "\n",
+		},
+		{
+			name: "nullable_types_sql",
+			json: "" +
+				`{"count":null,"duration":null,"name":null,"ok":null,"ratio":null,"time":null}` +
+				`{"count":1,"duration":"30s","name":"a","ok":true,"ratio":0.5,"time":"2024-01-01T00:00:00Z"}`,
+			generatorOptions: []GeneratorOption{
+				WithDetectDurations(true),
+				WithNullableTypes(NullableTypesSQL),
+			},
+			expectedGoCodeStr: "" +
+				"package main\n" +
+				"\n" +
+				"import (\n" +
+				"\t\"database/sql\"\n" +
+				"\t\"encoding/json\"\n" +
+				"\t\"time\"\n" +
+				")\n" +
+				"\n" +
+				"type T struct {\n" +
+				"\tCount    NullInt64      `json:\"count\"`\n" +
+				"\tDuration Null[Duration] `json:\"duration\"`\n" +
+				"\tName     NullString     `json:\"name\"`\n" +
+				"\tOk       NullBool       `json:\"ok\"`\n" +
+				"\tRatio    NullFloat64    `json:\"ratio\"`\n" +
+				"\tTime     NullTime       `json:\"time\"`\n" +
+				"}\n" +
+				"\n" +
+				durationHelperDecl + "\n" +
+				"\n" +
+				sqlNullGenericHelperDecl + "\n" +
+				"\n" +
+				fmt.Sprintf(sqlNullHelperDeclFormat, "NullBool", "Bool") + "\n" +
+				"\n" +
+				fmt.Sprintf(sqlNullHelperDeclFormat, "NullFloat64", "Float64") + "\n" +
+				"\n" +
+				fmt.Sprintf(sqlNullHelperDeclFormat, "NullInt64", "Int64") + "\n" +
+				"\n" +
+				fmt.Sprintf(sqlNullHelperDeclFormat, "NullString", "String") + "\n" +
+				"\n" +
+				fmt.Sprintf(sqlNullHelperDeclFormat, "NullTime", "Time") + "\n",
+		},
+		{
+			name: "nullable_types_sql_time",
+			json: "" +
+				`{"deleted":null,"id":1}` +
+				`{"deleted":"2024-01-01T00:00:00Z","id":2}`,
+			generatorOptions: []GeneratorOption{
+				WithNullableTypes(NullableTypesSQL),
+				WithPointers(PointersAbsentOrNull),
+			},
+			expectedGoCodeStr: "" +
+				"package main\n" +
+				"\n" +
+				"import (\n" +
+				"\t\"database/sql\"\n" +
+				"\t\"encoding/json\"\n" +
+				")\n" +
+				"\n" +
+				"type T struct {\n" +
+				"\tDeleted NullTime `json:\"deleted\"`\n" +
+				"\tID      int      `json:\"id\"`\n" +
+				"}\n" +
+				"\n" +
+				fmt.Sprintf(sqlNullHelperDeclFormat, "NullTime", "Time") + "\n",
+		},
+		{
+			name: "nullable_types_sql_pointers_absent_or_null",
+			json: "" +
+				`{"count":1,"time":"2024-01-01T00:00:00Z"}` +
+				`{"count":null,"time":null}` +
+				`{}`,
+			generatorOptions: []GeneratorOption{
+				WithNullableTypes(NullableTypesSQL),
+				WithPointers(PointersAbsentOrNull),
+			},
+			expectedGoCodeStr: "" +
+				"package main\n" +
+				"\n" +
+				"import (\n" +
+				"\t\"database/sql\"\n" +
+				"\t\"encoding/json\"\n" +
+				")\n" +
+				"\n" +
+				"type T struct {\n" +
+				"\tCount NullInt64 `json:\"count,omitempty,omitzero\"`\n" +
+				"\tTime  NullTime  `json:\"time,omitempty,omitzero\"`\n" +
+				"}\n" +
+				"\n" +
+				fmt.Sprintf(sqlNullHelperDeclFormat, "NullInt64", "Int64") + "\n" +
+				"\n" +
+				fmt.Sprintf(sqlNullHelperDeclFormat, "NullTime", "Time") + "\n",
+		},
+		{
+			name: "nullable_types_sql_pointers_null_only",
+			json: "" +
+				`{"count":1,"time":"2024-01-01T00:00:00Z"}` +
+				`{"count":null,"time":null}` +
+				`{}`,
+			generatorOptions: []GeneratorOption{
+				WithNullableTypes(NullableTypesSQL),
+				WithPointers(PointersNullOnly),
+			},
+			expectedGoCodeStr: "" +
+				"package main\n" +
+				"\n" +
+				"import (\n" +
+				"\t\"database/sql\"\n" +
+				"\t\"encoding/json\"\n" +
+				")\n" +
+				"\n" +
+				"type T struct {\n" +
+				"\tCount NullInt64 `json:\"count,omitempty,omitzero\"`\n" +
+				"\tTime  NullTime  `json:\"time,omitempty,omitzero\"`\n" +
+				"}\n" +
+				"\n" +
+				fmt.Sprintf(sqlNullHelperDeclFormat, "NullInt64", "Int64") + "\n" +
+				"\n" +
+				fmt.Sprintf(sqlNullHelperDeclFormat, "NullTime", "Time") + "\n",
+		},
+		{
+			name: "nullable_types_sql_pointers_never",
+			json: "" +
+				`{"count":1,"time":"2024-01-01T00:00:00Z"}` +
+				`{"count":null,"time":null}` +
+				`{}`,
+			generatorOptions: []GeneratorOption{
+				WithNullableTypes(NullableTypesSQL),
+				WithPointers(PointersNever),
+			},
+			expectedGoCodeStr: "" +
+				"package main\n" +
+				"\n" +
+				"import (\n" +
+				"\t\"database/sql\"\n" +
+				"\t\"encoding/json\"\n" +
+				")\n" +
+				"\n" +
+				"type T struct {\n" +
+				"\tCount NullInt64 `json:\"count,omitempty,omitzero\"`\n" +
+				"\tTime  NullTime  `json:\"time,omitempty,omitzero\"`\n" +
+				"}\n" +
+				"\n" +
+				fmt.Sprintf(sqlNullHelperDeclFormat, "NullInt64", "Int64") + "\n" +
+				"\n" +
+				fmt.Sprintf(sqlNullHelperDeclFormat, "NullTime", "Time") + "\n",
+		},
+		{
+			name: "pointers_absent_or_null",
+			json: "" +
+				`{"flag":null,"id":1,"name":"a","object":{"id":1},"tags":["a"],"time":"2024-01-01T00:00:00Z"}` +
+				`{"flag":true,"id":2}`,
+			generatorOptions: []GeneratorOption{
+				WithPointers(PointersAbsentOrNull),
+			},
+			expectedGoCodeStr: "" +
+				"package main\n" +
+				"\n" +
+				"import (\n" +
+				"\t\"time\"\n" +
+				")\n" +
+				"\n" +
+				"type T struct {\n" +
+				"\tFlag   *bool   `json:\"flag\"`\n" +
+				"\tID     int     `json:\"id\"`\n" +
+				"\tName   *string `json:\"name,omitempty\"`\n" +
+				"\tObject *struct {\n" +
+				"\t\tID int `json:\"id\"`\n" +
+				"\t} `json:\"object,omitempty\"`\n" +
+				"\tTags []string   `json:\"tags,omitempty\"`\n" +
+				"\tTime *time.Time `json:\"time,omitempty\"`\n" +
+				"}\n",
+		},
+		{
+			name: "pointers_absent_or_null_deduplicate_types_compatible",
+			json: `{"a":{"x":1},"b":{"x":2,"y":"s"}}{}`,
+			generatorOptions: []GeneratorOption{
+				WithDeduplicateTypes(DeduplicateTypesCompatible),
+				WithPointers(PointersAbsentOrNull),
+			},
+			expectedGoCodeStr: "" +
+				"package main\n" +
+				"\n" +
+				"type T struct {\n" +
+				"\tA *A `json:\"a,omitempty\"`\n" +
+				"\tB *A `json:\"b,omitempty\"`\n" +
+				"}\n" +
+				"\n" +
+				"type A struct {\n" +
+				"\tX int     `json:\"x\"`\n" +
+				"\tY *string `json:\"y,omitempty\"`\n" +
+				"}\n",
+		},
+		{
+			name: "pointers_null_only",
+			json: "" +
+				`{"flag":null,"id":1,"name":"a","object":{"id":1},"tags":["a"],"time":"2024-01-01T00:00:00Z"}` +
+				`{"flag":true,"id":2}`,
+			generatorOptions: []GeneratorOption{
+				WithPointers(PointersNullOnly),
+			},
+			expectedGoCodeStr: "" +
+				"package main\n" +
+				"\n" +
+				"import (\n" +
+				"\t\"time\"\n" +
+				")\n" +
+				"\n" +
+				"type T struct {\n" +
+				"\tFlag   *bool  `json:\"flag\"`\n" +
+				"\tID     int    `json:\"id\"`\n" +
+				"\tName   string `json:\"name,omitzero\"`\n" +
+				"\tObject struct {\n" +
+				"\t\tID int `json:\"id\"`\n" +
+				"\t} `json:\"object,omitzero\"`\n" +
+				"\tTags []string  `json:\"tags,omitempty\"`\n" +
+				"\tTime time.Time `json:\"time,omitzero\"`\n" +
+				"}\n",
+		},
+		{
+			name: "pointers_never",
+			json: "" +
+				`{"flag":null,"id":1,"name":"a","object":{"id":1},"tags":["a"],"time":"2024-01-01T00:00:00Z"}` +
+				`{"flag":true,"id":2}`,
+			generatorOptions: []GeneratorOption{
+				WithPointers(PointersNever),
+			},
+			expectedGoCodeStr: "" +
+				"package main\n" +
+				"\n" +
+				"import (\n" +
+				"\t\"time\"\n" +
+				")\n" +
+				"\n" +
+				"type T struct {\n" +
+				"\tFlag   bool   `json:\"flag,omitzero\"`\n" +
+				"\tID     int    `json:\"id\"`\n" +
+				"\tName   string `json:\"name,omitzero\"`\n" +
+				"\tObject struct {\n" +
+				"\t\tID int `json:\"id\"`\n" +
+				"\t} `json:\"object,omitzero\"`\n" +
+				"\tTags []string  `json:\"tags,omitzero\"`\n" +
+				"\tTime time.Time `json:\"time,omitzero\"`\n" +
+				"}\n",
+		},
+		{
+			name: "pointers_never_string_enums",
+			json: `{"p":"a"}{"p":"b"}{"p":"a"}{"p":"b"}{"p":null}`,
+			generatorOptions: []GeneratorOption{
+				WithPointers(PointersNever),
+				WithStringEnums(3),
+			},
+			expectedGoCodeStr: "" +
+				"package main\n" +
+				"\n" +
+				"type T struct {\n" +
+				"\tP P `json:\"p,omitzero\"`\n" +
+				"}\n" +
+				"\n" +
+				"type P string\n" +
+				"\n" +
+				"const (\n" +
+				"\tPA P = \"a\"\n" +
+				"\tPB P = \"b\"\n" +
+				")\n",
+		},
+		{
+			name: "pointers_never_unions",
+			json: `{"p":{"type":"a","x":1}}{"p":{"type":"b","y":"s"}}{"p":{"type":"a","x":2}}{"p":{"type":"b","y":"t"}}{"p":null}`,
+			generatorOptions: []GeneratorOption{
+				WithDetectUnions(true),
+				WithPointers(PointersNever),
+			},
+			expectedGoCodeStr: "" +
+				"package main\n" +
+				"\n" +
+				"import (\n" +
+				"\t\"encoding/json\"\n" +
+				"\t\"fmt\"\n" +
+				")\n" +
+				"\n" +
+				"type T struct {\n" +
+				"\tP *P `json:\"p\"`\n" +
+				"}\n" +
+				"\n" +
+				"type P struct {\n" +
+				"\tValue PValue\n" +
+				"}\n" +
+				"\n" +
+				"// UnmarshalJSON implements encoding/json.Unmarshaler.\n" +
+				"func (v *P) UnmarshalJSON(data []byte) error {\n" +
+				"\tvar discriminator struct {\n" +
+				"\t\tValue string `json:\"type\"`\n" +
+				"\t}\n" +
+				"\tif err := json.Unmarshal(data, &discriminator); err != nil {\n" +
+				"\t\treturn err\n" +
+				"\t}\n" +
+				"\tswitch discriminator.Value {\n" +
+				"\tcase \"a\":\n" +
+				"\t\tvar value PA\n" +
+				"\t\tif err := json.Unmarshal(data, &value); err != nil {\n" +
+				"\t\t\treturn err\n" +
+				"\t\t}\n" +
+				"\t\tv.Value = value\n" +
+				"\tcase \"b\":\n" +
+				"\t\tvar value PB\n" +
+				"\t\tif err := json.Unmarshal(data, &value); err != nil {\n" +
+				"\t\t\treturn err\n" +
+				"\t\t}\n" +
+				"\t\tv.Value = value\n" +
+				"\tdefault:\n" +
+				"\t\treturn fmt.Errorf(\"%q: unknown type\", discriminator.Value)\n" +
+				"\t}\n" +
+				"\treturn nil\n" +
+				"}\n" +
+				"\n" +
+				"// MarshalJSON implements encoding/json.Marshaler.\n" +
+				"func (v P) MarshalJSON() ([]byte, error) {\n" +
+				"\treturn json.Marshal(v.Value)\n" +
+				"}\n" +
+				"\n" +
+				"type PA struct {\n" +
+				"\tType string `json:\"type\"`\n" +
+				"\tX    int    `json:\"x\"`\n" +
+				"}\n" +
+				"\n" +
+				"func (PA) isPValue() {}\n" +
+				"\n" +
+				"type PB struct {\n" +
+				"\tType string `json:\"type\"`\n" +
+				"\tY    string `json:\"y\"`\n" +
+				"}\n" +
+				"\n" +
+				"func (PB) isPValue() {}\n" +
+				"\n" +
+				"// PValue is one of PA or PB, depending on the value of \"type\".\n" +
+				"type PValue interface {\n" +
+				"\tisPValue()\n" +
+				"}\n",
+		},
+		{
+			name: "field_name_collisions",
+			json: `{"ID":1,"id":2,"userId":"a","user_id":"b"}`,
+			expectedGoCodeStr: "" +
+				"package main\n" +
+				"\n" +
+				"type T struct {\n" +
+				"\tID      int    `json:\"ID\"`\n" +
+				"\tID2     int    `json:\"id\"` // renamed to avoid a collision with \"ID\"\n" +
+				"\tUserID  string `json:\"userId\"`\n" +
+				"\tUserID2 string `json:\"user_id\"` // renamed to avoid a collision with \"userId\"\n" +
+				"}\n",
+		},
+		{
+			name: "non_latin_properties",
+			json: `{"café":1,"имя":"a","日本":{"東京":true},"!!!":2}`,
+			generatorOptions: []GeneratorOption{
+				WithExtractNestedTypes(true),
+			},
+			expectedGoCodeStr: "" +
+				"package main\n" +
+				"\n" +
+				"type T struct {\n" +
+				"\tTProperty int    `json:\"!!!\"`\n" +
+				"\tCafe      int    `json:\"café\"`\n" +
+				"\tImya      string `json:\"имя\"`\n" +
+				"\tX日本       X日本    `json:\"日本\"`\n" +
+				"}\n" +
+				"\n" +
+				"type X日本 struct {\n" +
+				"\tX東京 bool `json:\"東京\"`\n" +
+				"}\n",
+		},
+		{
+			name: "cjk_properties",
+			json: `{"名前":"a","名字":"b"}`,
+			expectedGoCodeStr: "" +
+				"package main\n" +
+				"\n" +
+				"type T struct {\n" +
+				"\tX名前 string `json:\"名前\"`\n" +
+				"\tX名字 string `json:\"名字\"`\n" +
+				"}\n",
+		},
+		{
+			name: "unparsable_properties_comment",
+			json: `{"-":1,"a` + "`" + `b":true,"display name":"a","id":1}`,
+			expectedGoCodeStr: "" +
+				"package main\n" +
+				"\n" +
+				"type T struct {\n" +
+				"\tTProperty int `json:\"-,\"`\n" +
+				"\tID        int `json:\"id\"`\n" +
+				"\t// \"a`b\" cannot be unmarshalled into a struct field by encoding/json.\n" +
+				"\t// \"display name\" cannot be unmarshalled into a struct field by encoding/json.\n" +
+				"}\n",
+		},
+		{
+			name: "unparsable_properties_comment_symbols",
+			json: `{"a,b":true,"it's":1,"€":"a"}`,
+			generatorOptions: []GeneratorOption{
+				WithSkipUnparsableProperties(false),
+			},
+			expectedGoCodeStr: "" +
+				"package main\n" +
+				"\n" +
+				"type T struct {\n" +
+				"\tIt_S      int    `json:\"it's\"`\n" +
+				"\tTProperty string `json:\"€\"`\n" +
+				"\t// \"a,b\" cannot be unmarshalled into a struct field by encoding/json.\n" +
+				"}\n",
+		},
+		{
+			name: "unparsable_properties_methods",
+			json: `{"-":1,"a` + "`" + `b":true,"display name":"a","id":1}`,
+			generatorOptions: []GeneratorOption{
+				WithUnparsableProperties(UnparsablePropertiesMethods),
+			},
+			expectedGoCodeStr: "" +
+				"package main\n" +
+				"\n" +
+				"import (\n" +
+				"\t\"encoding/json\"\n" +
+				")\n" +
+				"\n" +
+				"type T struct {\n" +
+				"\tTProperty    int    `json:\"-,\"`\n" +
+				"\tA_B          bool   `json:\"-\"`\n" +
+				"\tDisplay_Name string `json:\"-\"`\n" +
+				"\tID           int    `json:\"id\"`\n" +
+				"}\n" +
+				"\n" +
+				"// UnmarshalJSON implements encoding/json.Unmarshaler.\n" +
+				"func (t *T) UnmarshalJSON(data []byte) error {\n" +
+				"\ttype plain T\n" +
+				"\tif err := json.Unmarshal(data, (*plain)(t)); err != nil {\n" +
+				"\t\treturn err\n" +
+				"\t}\n" +
+				"\tvar properties map[string]json.RawMessage\n" +
+				"\tif err := json.Unmarshal(data, &properties); err != nil {\n" +
+				"\t\treturn err\n" +
+				"\t}\n" +
+				"\tif value, ok := properties[\"a`b\"]; ok {\n" +
+				"\t\tif err := json.Unmarshal(value, &t.A_B); err != nil {\n" +
+				"\t\t\treturn err\n" +
+				"\t\t}\n" +
+				"\t}\n" +
+				"\tif value, ok := properties[\"display name\"]; ok {\n" +
+				"\t\tif err := json.Unmarshal(value, &t.Display_Name); err != nil {\n" +
+				"\t\t\treturn err\n" +
+				"\t\t}\n" +
+				"\t}\n" +
+				"\treturn nil\n" +
+				"}\n" +
+				"\n" +
+				"// MarshalJSON implements encoding/json.Marshaler.\n" +
+				"func (t T) MarshalJSON() ([]byte, error) {\n" +
+				"\ttype plain T\n" +
+				"\tdata, err := json.Marshal(plain(t))\n" +
+				"\tif err != nil {\n" +
+				"\t\treturn nil, err\n" +
+				"\t}\n" +
+				"\tvar properties map[string]json.RawMessage\n" +
+				"\tif err := json.Unmarshal(data, &properties); err != nil {\n" +
+				"\t\treturn nil, err\n" +
+				"\t}\n" +
+				"\tif properties[\"a`b\"], err = json.Marshal(t.A_B); err != nil {\n" +
+				"\t\treturn nil, err\n" +
+				"\t}\n" +
+				"\tif properties[\"display name\"], err = json.Marshal(t.Display_Name); err != nil {\n" +
+				"\t\treturn nil, err\n" +
+				"\t}\n" +
+				"\treturn json.Marshal(properties)\n" +
+				"}\n",
+		},
+		{
+			name: "unparsable_properties_methods_absent",
+			json: `{"display name":"a","id":1}{"id":2}`,
+			generatorOptions: []GeneratorOption{
+				WithUnparsableProperties(UnparsablePropertiesMethods),
+			},
+			expectedGoCodeStr: "" +
+				"package main\n" +
+				"\n" +
+				"import (\n" +
+				"\t\"encoding/json\"\n" +
+				"\t\"reflect\"\n" +
+				")\n" +
+				"\n" +
+				"type T struct {\n" +
+				"\tDisplay_Name string `json:\"-\"`\n" +
+				"\tID           int    `json:\"id\"`\n" +
+				"}\n" +
+				"\n" +
+				"// UnmarshalJSON implements encoding/json.Unmarshaler.\n" +
+				"func (t *T) UnmarshalJSON(data []byte) error {\n" +
+				"\ttype plain T\n" +
+				"\tif err := json.Unmarshal(data, (*plain)(t)); err != nil {\n" +
+				"\t\treturn err\n" +
+				"\t}\n" +
+				"\tvar properties map[string]json.RawMessage\n" +
+				"\tif err := json.Unmarshal(data, &properties); err != nil {\n" +
+				"\t\treturn err\n" +
+				"\t}\n" +
+				"\tif value, ok := properties[\"display name\"]; ok {\n" +
+				"\t\tif err := json.Unmarshal(value, &t.Display_Name); err != nil {\n" +
+				"\t\t\treturn err\n" +
+				"\t\t}\n" +
+				"\t}\n" +
+				"\treturn nil\n" +
+				"}\n" +
+				"\n" +
+				"// MarshalJSON implements encoding/json.Marshaler.\n" +
+				"func (t T) MarshalJSON() ([]byte, error) {\n" +
+				"\ttype plain T\n" +
+				"\tdata, err := json.Marshal(plain(t))\n" +
+				"\tif err != nil {\n" +
+				"\t\treturn nil, err\n" +
+				"\t}\n" +
+				"\tvar properties map[string]json.RawMessage\n" +
+				"\tif err := json.Unmarshal(data, &properties); err != nil {\n" +
+				"\t\treturn nil, err\n" +
+				"\t}\n" +
+				"\tif !reflect.ValueOf(t.Display_Name).IsZero() {\n" +
+				"\t\tif properties[\"display name\"], err = json.Marshal(t.Display_Name); err != nil {\n" +
+				"\t\t\treturn nil, err\n" +
+				"\t\t}\n" +
+				"\t}\n" +
+				"\treturn json.Marshal(properties)\n" +
+				"}\n",
+		},
+		{
+			name: "unparsable_properties_methods_method_name_collision",
+			json: `{"MarshalJSON":1,"a b":2}`,
+			generatorOptions: []GeneratorOption{
+				WithUnparsableProperties(UnparsablePropertiesMethods),
+			},
+			expectedGoCodeStr: "" +
+				"package main\n" +
+				"\n" +
+				"import (\n" +
+				"\t\"encoding/json\"\n" +
+				")\n" +
+				"\n" +
+				"type T struct {\n" +
+				"\tMarshalJSON2 int `json:\"MarshalJSON\"` // renamed to avoid a collision with the MarshalJSON method\n" +
+				"\tA_B          int `json:\"-\"`\n" +
+				"}\n" +
+				"\n" +
+				"// UnmarshalJSON implements encoding/json.Unmarshaler.\n" +
+				"func (t *T) UnmarshalJSON(data []byte) error {\n" +
+				"\ttype plain T\n" +
+				"\tif err := json.Unmarshal(data, (*plain)(t)); err != nil {\n" +
+				"\t\treturn err\n" +
+				"\t}\n" +
+				"\tvar properties map[string]json.RawMessage\n" +
+				"\tif err := json.Unmarshal(data, &properties); err != nil {\n" +
+				"\t\treturn err\n" +
+				"\t}\n" +
+				"\tif value, ok := properties[\"a b\"]; ok {\n" +
+				"\t\tif err := json.Unmarshal(value, &t.A_B); err != nil {\n" +
+				"\t\t\treturn err\n" +
+				"\t\t}\n" +
+				"\t}\n" +
+				"\treturn nil\n" +
+				"}\n" +
+				"\n" +
+				"// MarshalJSON implements encoding/json.Marshaler.\n" +
+				"func (t T) MarshalJSON() ([]byte, error) {\n" +
+				"\ttype plain T\n" +
+				"\tdata, err := json.Marshal(plain(t))\n" +
+				"\tif err != nil {\n" +
+				"\t\treturn nil, err\n" +
+				"\t}\n" +
+				"\tvar properties map[string]json.RawMessage\n" +
+				"\tif err := json.Unmarshal(data, &properties); err != nil {\n" +
+				"\t\treturn nil, err\n" +
+				"\t}\n" +
+				"\tif properties[\"a b\"], err = json.Marshal(t.A_B); err != nil {\n" +
+				"\t\treturn nil, err\n" +
+				"\t}\n" +
+				"\treturn json.Marshal(properties)\n" +
+				"}\n",
+		},
+		{
+			name: "unparsable_properties_methods_empty",
+			json: `{"":1,"a":2}`,
+			generatorOptions: []GeneratorOption{
+				WithUnparsableProperties(UnparsablePropertiesMethods),
+			},
+			expectedGoCodeStr: "" +
+				"package main\n" +
+				"\n" +
+				"import (\n" +
+				"\t\"encoding/json\"\n" +
+				")\n" +
+				"\n" +
+				"type T struct {\n" +
+				"\tTProperty int `json:\"-\"`\n" +
+				"\tA         int `json:\"a\"`\n" +
+				"}\n" +
+				"\n" +
+				"// UnmarshalJSON implements encoding/json.Unmarshaler.\n" +
+				"func (t *T) UnmarshalJSON(data []byte) error {\n" +
+				"\ttype plain T\n" +
+				"\tif err := json.Unmarshal(data, (*plain)(t)); err != nil {\n" +
+				"\t\treturn err\n" +
+				"\t}\n" +
+				"\tvar properties map[string]json.RawMessage\n" +
+				"\tif err := json.Unmarshal(data, &properties); err != nil {\n" +
+				"\t\treturn err\n" +
+				"\t}\n" +
+				"\tif value, ok := properties[\"\"]; ok {\n" +
+				"\t\tif err := json.Unmarshal(value, &t.TProperty); err != nil {\n" +
+				"\t\t\treturn err\n" +
+				"\t\t}\n" +
+				"\t}\n" +
+				"\treturn nil\n" +
+				"}\n" +
+				"\n" +
+				"// MarshalJSON implements encoding/json.Marshaler.\n" +
+				"func (t T) MarshalJSON() ([]byte, error) {\n" +
+				"\ttype plain T\n" +
+				"\tdata, err := json.Marshal(plain(t))\n" +
+				"\tif err != nil {\n" +
+				"\t\treturn nil, err\n" +
+				"\t}\n" +
+				"\tvar properties map[string]json.RawMessage\n" +
+				"\tif err := json.Unmarshal(data, &properties); err != nil {\n" +
+				"\t\treturn nil, err\n" +
+				"\t}\n" +
+				"\tif properties[\"\"], err = json.Marshal(t.TProperty); err != nil {\n" +
+				"\t\treturn nil, err\n" +
+				"\t}\n" +
+				"\treturn json.Marshal(properties)\n" +
+				"}\n",
+		},
+		{
+			name: "detect_recursive_types",
+			json: `{"id":1,"children":[{"id":2,"children":[{"id":3}]}]}`,
+			generatorOptions: []GeneratorOption{
+				WithDetectRecursiveTypes(true),
+			},
+			expectedGoCodeStr: "" +
+				"package main\n" +
+				"\n" +
+				"type T struct {\n" +
+				"\tChildren []*T `json:\"children,omitempty\"`\n" +
+				"\tID       int  `json:\"id\"`\n" +
+				"}\n",
+		},
+		{
+			name: "detect_recursive_types_optional",
+			json: "" +
+				`{"name":"a","tree":{"id":1,"children":[{"id":2,"children":[{"id":3}]}]}}` +
+				`{"name":"b","tree":null}` +
+				`{"name":"c"}`,
+			generatorOptions: []GeneratorOption{
+				WithDetectRecursiveTypes(true),
+				WithOptionalTypes(true),
+			},
+			expectedGoCodeStr: "" +
+				"package main\n" +
+				"\n" +
+				"import (\n" +
+				"\t\"encoding/json\"\n" +
+				")\n" +
+				"\n" +
+				"type T struct {\n" +
+				"\tName string         `json:\"name\"`\n" +
+				"\tTree Optional[Tree] `json:\"tree,omitzero\"`\n" +
+				"}\n" +
+				"\n" +
+				"type Tree struct {\n" +
+				"\tChildren []*Tree `json:\"children,omitempty\"`\n" +
+				"\tID       int     `json:\"id\"`\n" +
+				"}\n" +
+				"\n" +
+				optionalHelperDecl + "\n",
+		},
+		{
+			name: "detect_recursive_types_sibling_objects",
+			json: `{"id":1,"meta":{"id":7},"children":[{"id":2,"meta":{"id":8},"children":[{"id":3}]}]}`,
+			generatorOptions: []GeneratorOption{
+				WithDetectRecursiveTypes(true),
+			},
+			expectedGoCodeStr: "" +
+				"package main\n" +
+				"\n" +
+				"type T struct {\n" +
+				"\tChildren []*T `json:\"children,omitempty\"`\n" +
+				"\tID       int  `json:\"id\"`\n" +
+				"\tMeta     *struct {\n" +
+				"\t\tID int `json:\"id\"`\n" +
+				"\t} `json:\"meta,omitempty\"`\n" +
+				"}\n",
+		},
+		{
+			name: "detect_recursive_types_nested_sibling_objects",
+			json: `{"tree":{"id":1,"children":[{"id":2,"children":[{"id":3}]}]},"other":{"tree":{"id":4}}}`,
+			generatorOptions: []GeneratorOption{
+				WithDetectRecursiveTypes(true),
+			},
+			expectedGoCodeStr: "" +
+				"package main\n" +
+				"\n" +
+				"type T struct {\n" +
+				"\tOther struct {\n" +
+				"\t\tTree struct {\n" +
+				"\t\t\tID int `json:\"id\"`\n" +
+				"\t\t} `json:\"tree\"`\n" +
+				"\t} `json:\"other\"`\n" +
+				"\tTree Tree `json:\"tree\"`\n" +
+				"}\n" +
+				"\n" +
+				"type Tree struct {\n" +
+				"\tChildren []*Tree `json:\"children,omitempty\"`\n" +
+				"\tID       int     `json:\"id\"`\n" +
+				"}\n",
+		},
+		{
+			name: "detect_recursive_types_nested",
+			json: `{"name":"root","tree":{"id":1,"label":"a","children":[{"id":2,"label":"b","children":[{"id":3,"label":"c"}]}]}}`,
+			generatorOptions: []GeneratorOption{
+				WithDetectRecursiveTypes(true),
+			},
+			expectedGoCodeStr: "" +
+				"package main\n" +
+				"\n" +
+				"type T struct {\n" +
+				"\tName string `json:\"name\"`\n" +
+				"\tTree Tree   `json:\"tree\"`\n" +
+				"}\n" +
+				"\n" +
+				"type Tree struct {\n" +
+				"\tChildren []*Tree `json:\"children,omitempty\"`\n" +
+				"\tID       int     `json:\"id\"`\n" +
+				"\tLabel    string  `json:\"label\"`\n" +
+				"}\n",
+		},
+		{
+			name: "detect_string_formats",
+			json: "" +
+				`{"gateway":null,"hosts":["a.example.com"],"id":"123e4567-e89b-12d3-a456-426614174000","ip":"192.168.0.1","network":"10.0.0.0/8","version":"1.5"}` +
+				`{"gateway":"10.0.0.1","hosts":[],"id":"123e4567-e89b-12d3-a456-426614174001","ip":"::1","network":"2001:db8::/32","version":"2.0"}`,
+			generatorOptions: []GeneratorOption{
+				WithStringFormats(DefaultStringFormats()...),
+			},
+			expectedGoCodeStr: "" +
+				"package main\n" +
+				"\n" +
+				"import (\n" +
+				"\t\"net/netip\"\n" +
+				")\n" +
+				"\n" +
+				"type T struct {\n" +
+				"\tGateway *netip.Addr  `json:\"gateway\"`\n" +
+				"\tHosts   []string     `json:\"hosts\"` // hostname\n" +
+				"\tID      string       `json:\"id\"`    // UUID\n" +
+				"\tIp      netip.Addr   `json:\"ip\"`\n" +
+				"\tNetwork netip.Prefix `json:\"network\"`\n" +
+				"\tVersion string       `json:\"version\"`\n" +
+				"}\n",
+		},
+		{
+			name: "detect_tuples",
+			json: "" +
+				`{"coordinates":[1.5,2.5],"pair":["x",1],"tags":["a","b"]}` +
+				`{"coordinates":[3,4.5],"pair":["y",2],"tags":["c"]}` +
+				`{"coordinates":[5,6],"pair":["z",3],"tags":["d","e"]}`,
+			generatorOptions: []GeneratorOption{
+				WithDetectTuples(true),
+			},
+			expectedGoCodeStr: "" +
+				"package main\n" +
+				"\n" +
+				"import (\n" +
+				"\t\"encoding/json\"\n" +
+				"\t\"fmt\"\n" +
+				")\n" +
+				"\n" +
+				"type T struct {\n" +
+				"\tCoordinates [2]float64 `json:\"coordinates\"`\n" +
+				"\tPair        Pair       `json:\"pair\"`\n" +
+				"\tTags        []string   `json:\"tags\"`\n" +
+				"}\n" +
+				"\n" +
+				"type Pair struct {\n" +
+				"\tV0 string\n" +
+				"\tV1 int\n" +
+				"}\n" +
+				"\n" +
+				"// UnmarshalJSON implements encoding/json.Unmarshaler.\n" +
+				"func (t *Pair) UnmarshalJSON(data []byte) error {\n" +
+				"\tif string(data) == \"null\" {\n" +
+				"\t\treturn nil\n" +
+				"\t}\n" +
+				"\tvar elements []json.RawMessage\n" +
+				"\tif err := json.Unmarshal(data, &elements); err != nil {\n" +
+				"\t\treturn err\n" +
+				"\t}\n" +
+				"\tif len(elements) != 2 {\n" +
+				"\t\treturn fmt.Errorf(\"%d: invalid number of Pair elements\", len(elements))\n" +
+				"\t}\n" +
+				"\tif err := json.Unmarshal(elements[0], &t.V0); err != nil {\n" +
+				"\t\treturn err\n" +
+				"\t}\n" +
+				"\tif err := json.Unmarshal(elements[1], &t.V1); err != nil {\n" +
+				"\t\treturn err\n" +
+				"\t}\n" +
+				"\treturn nil\n" +
+				"}\n" +
+				"\n" +
+				"// MarshalJSON implements encoding/json.Marshaler.\n" +
+				"func (t Pair) MarshalJSON() ([]byte, error) {\n" +
+				"\treturn json.Marshal([]any{t.V0, t.V1})\n" +
+				"}\n",
+		},
+		{
+			name: "detect_tuples_deduplicate_types",
+			json: "" +
+				`{"a":{"p":["x",1]},"b":{"p":["y",2]},"tags":["a","b"]}` +
+				`{"a":{"p":["z",1]},"b":{"p":["w",2]},"tags":["c","d"]}`,
+			generatorOptions: []GeneratorOption{
+				WithDeduplicateTypes(DeduplicateTypesIdentical),
+				WithDetectTuples(true),
+			},
+			expectedGoCodeStr: "" +
+				"package main\n" +
+				"\n" +
+				"import (\n" +
+				"\t\"encoding/json\"\n" +
+				"\t\"fmt\"\n" +
+				")\n" +
+				"\n" +
+				"type T struct {\n" +
+				"\tA    A        `json:\"a\"`\n" +
+				"\tB    A        `json:\"b\"`\n" +
+				"\tTags []string `json:\"tags\"`\n" +
+				"}\n" +
+				"\n" +
+				"type A struct {\n" +
+				"\tP P `json:\"p\"`\n" +
+				"}\n" +
+				"\n" +
+				"type P struct {\n" +
+				"\tV0 string\n" +
+				"\tV1 int\n" +
+				"}\n" +
+				"\n" +
+				"// UnmarshalJSON implements encoding/json.Unmarshaler.\n" +
+				"func (t *P) UnmarshalJSON(data []byte) error {\n" +
+				"\tif string(data) == \"null\" {\n" +
+				"\t\treturn nil\n" +
+				"\t}\n" +
+				"\tvar elements []json.RawMessage\n" +
+				"\tif err := json.Unmarshal(data, &elements); err != nil {\n" +
+				"\t\treturn err\n" +
+				"\t}\n" +
+				"\tif len(elements) != 2 {\n" +
+				"\t\treturn fmt.Errorf(\"%d: invalid number of P elements\", len(elements))\n" +
+				"\t}\n" +
+				"\tif err := json.Unmarshal(elements[0], &t.V0); err != nil {\n" +
+				"\t\treturn err\n" +
+				"\t}\n" +
+				"\tif err := json.Unmarshal(elements[1], &t.V1); err != nil {\n" +
+				"\t\treturn err\n" +
+				"\t}\n" +
+				"\treturn nil\n" +
+				"}\n" +
+				"\n" +
+				"// MarshalJSON implements encoding/json.Marshaler.\n" +
+				"func (t P) MarshalJSON() ([]byte, error) {\n" +
+				"\treturn json.Marshal([]any{t.V0, t.V1})\n" +
+				"}\n",
+		},
+		{
+			name: "detect_tuples_any_element_decimal",
+			json: `{"p":[1.5,true]}{"p":[2.5,"x"]}`,
+			generatorOptions: []GeneratorOption{
+				WithDecimalNames("p"),
+				WithDetectDecimals(true),
+				WithDetectTuples(true),
+			},
+			expectedGoCodeStr: "" +
+				"package main\n" +
+				"\n" +
+				"type T struct {\n" +
+				"\tP []any `json:\"p\"`\n" +
+				"}\n",
+		},
+		{
+			name: "detect_tuples_any_element_string_format",
+			json: `{"p":["10.0.0.1",true]}{"p":["10.0.0.2","x"]}`,
+			generatorOptions: []GeneratorOption{
+				WithDetectTuples(true),
+				WithStringFormats(DefaultStringFormats()...),
+			},
+			expectedGoCodeStr: "" +
+				"package main\n" +
+				"\n" +
+				"type T struct {\n" +
+				"\tP []any `json:\"p\"`\n" +
+				"}\n",
+		},
+		{
+			name: "detect_tuples_nullable_root",
+			json: `["x",1]["y",2]null`,
+			generatorOptions: []GeneratorOption{
+				WithDetectTuples(true),
+			},
+			expectedGoCodeStr: "" +
+				"package main\n" +
+				"\n" +
+				"import (\n" +
+				"\t\"encoding/json\"\n" +
+				"\t\"fmt\"\n" +
+				")\n" +
+				"\n" +
+				"type T struct {\n" +
+				"\tV0 string\n" +
+				"\tV1 int\n" +
+				"}\n" +
+				"\n" +
+				"// UnmarshalJSON implements encoding/json.Unmarshaler.\n" +
+				"func (t *T) UnmarshalJSON(data []byte) error {\n" +
+				"\tif string(data) == \"null\" {\n" +
+				"\t\treturn nil\n" +
+				"\t}\n" +
+				"\tvar elements []json.RawMessage\n" +
+				"\tif err := json.Unmarshal(data, &elements); err != nil {\n" +
+				"\t\treturn err\n" +
+				"\t}\n" +
+				"\tif len(elements) != 2 {\n" +
+				"\t\treturn fmt.Errorf(\"%d: invalid number of T elements\", len(elements))\n" +
+				"\t}\n" +
+				"\tif err := json.Unmarshal(elements[0], &t.V0); err != nil {\n" +
+				"\t\treturn err\n" +
+				"\t}\n" +
+				"\tif err := json.Unmarshal(elements[1], &t.V1); err != nil {\n" +
+				"\t\treturn err\n" +
+				"\t}\n" +
+				"\treturn nil\n" +
+				"}\n" +
+				"\n" +
+				"// MarshalJSON implements encoding/json.Marshaler.\n" +
+				"func (t T) MarshalJSON() ([]byte, error) {\n" +
+				"\treturn json.Marshal([]any{t.V0, t.V1})\n" +
+				"}\n",
+		},
+		{
+			name: "detect_tuples_null_pointers_never",
+			json: `{"p":[1,"x"]}{"p":[2,"y"]}{"p":null}`,
+			generatorOptions: []GeneratorOption{
+				WithDetectTuples(true),
+				WithPointers(PointersNever),
+			},
+			expectedGoCodeStr: "" +
+				"package main\n" +
+				"\n" +
+				"import (\n" +
+				"\t\"encoding/json\"\n" +
+				"\t\"fmt\"\n" +
+				")\n" +
+				"\n" +
+				"type T struct {\n" +
+				"\tP P `json:\"p,omitzero\"`\n" +
+				"}\n" +
+				"\n" +
+				"type P struct {\n" +
+				"\tV0 int\n" +
+				"\tV1 string\n" +
+				"}\n" +
+				"\n" +
+				"// UnmarshalJSON implements encoding/json.Unmarshaler.\n" +
+				"func (t *P) UnmarshalJSON(data []byte) error {\n" +
+				"\tif string(data) == \"null\" {\n" +
+				"\t\treturn nil\n" +
+				"\t}\n" +
+				"\tvar elements []json.RawMessage\n" +
+				"\tif err := json.Unmarshal(data, &elements); err != nil {\n" +
+				"\t\treturn err\n" +
+				"\t}\n" +
+				"\tif len(elements) != 2 {\n" +
+				"\t\treturn fmt.Errorf(\"%d: invalid number of P elements\", len(elements))\n" +
+				"\t}\n" +
+				"\tif err := json.Unmarshal(elements[0], &t.V0); err != nil {\n" +
+				"\t\treturn err\n" +
+				"\t}\n" +
+				"\tif err := json.Unmarshal(elements[1], &t.V1); err != nil {\n" +
+				"\t\treturn err\n" +
+				"\t}\n" +
+				"\treturn nil\n" +
+				"}\n" +
+				"\n" +
+				"// MarshalJSON implements encoding/json.Marshaler.\n" +
+				"func (t P) MarshalJSON() ([]byte, error) {\n" +
+				"\treturn json.Marshal([]any{t.V0, t.V1})\n" +
+				"}\n",
+		},
+		{
+			name: "detect_epoch_times",
+			json: "" +
+				`{"count":3,"created_at":1700000000,"format":1700000000,"updatedAt":1700000000123}` +
+				`{"count":4,"created_at":1700000001,"format":1,"updatedAt":null}`,
+			generatorOptions: []GeneratorOption{
+				WithDetectEpochTimes(true),
+			},
+			expectedGoCodeStr: "" +
+				"package main\n" +
+				"\n" +
+				"import (\n" +
+				"\t\"encoding/json\"\n" +
+				"\t\"time\"\n" +
+				")\n" +
+				"\n" +
+				"type T struct {\n" +
+				"\tCount     int            `json:\"count\"`\n" +
+				"\tCreatedAt UnixTime       `json:\"created_at\"`\n" +
+				"\tFormat    int            `json:\"format\"`\n" +
+				"\tUpdatedAt *UnixMilliTime `json:\"updatedAt\"`\n" +
+				"}\n" +
+				"\n" +
+				fmt.Sprintf(epochHelperDeclFormat, "UnixMilliTime", "milliseconds", "t.UnixMilli()", "time.UnixMilli(i)") + "\n" +
+				"\n" +
+				fmt.Sprintf(epochHelperDeclFormat, "UnixTime", "seconds", "t.Unix()", "time.Unix(i, 0)") + "\n",
+		},
+		{
+			name: "omitzero_auto",
+			json: `{` +
+				`"nonZeroBool":true,"zeroBool":false,` +
+				`"nonZeroInt":1,"zeroInt":0,` +
+				`"nonZeroString":"s","zeroString":"",` +
+				`"nonZeroTime":"2025-01-01T00:00:00Z","zeroTime":"0001-01-01T00:00:00Z"` +
+				`}`,
+			generatorOptions: []GeneratorOption{
+				WithOmitZeroTags(OmitZeroTagsAuto),
+			},
+			expectedGoCodeStr: "" +
+				"package main\n" +
+				"\n" +
+				"import (\n" +
+				"\t\"time\"\n" +
+				")\n" +
+				"\n" +
+				"type T struct {\n" +
+				"\tNonZeroBool   bool      `json:\"nonZeroBool,omitzero\"`\n" +
+				"\tNonZeroInt    int       `json:\"nonZeroInt,omitzero\"`\n" +
+				"\tNonZeroString string    `json:\"nonZeroString,omitzero\"`\n" +
+				"\tNonZeroTime   time.Time `json:\"nonZeroTime,omitzero\"`\n" +
+				"\tZeroBool      bool      `json:\"zeroBool\"`\n" +
+				"\tZeroInt       int       `json:\"zeroInt\"`\n" +
+				"\tZeroString    string    `json:\"zeroString\"`\n" +
+				"\tZeroTime      time.Time `json:\"zeroTime\"`\n" +
+				"}\n",
+		},
+		{
+			name: "omitzero_always",
+			json: `{"zeroBool":false}`,
+			generatorOptions: []GeneratorOption{
+				WithOmitZeroTags(OmitZeroTagsAlways),
+			},
+			expectedGoCodeStr: "" +
+				"package main\n" +
+				"\n" +
+				"type T struct {\n" +
+				"\tZeroBool bool `json:\"zeroBool,omitzero\"`\n" +
+				"}\n",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.skip != "" {
+				t.Skip(tc.skip)
+			}
+			generator := NewGenerator(tc.generatorOptions...)
+			err := generator.ObserveJSONReader(bytes.NewBufferString(tc.json))
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			goCode, err := generator.Generate()
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedGoCodeStr, string(goCode))
+		})
+	}
+}
+
+func TestObserveYAMLGoCode(t *testing.T) {
+	for _, tc := range []struct {
+		skip              string
+		name              string
+		yaml              string
+		wantErr           bool
+		generatorOptions  []GeneratorOption
+		expectedGoCodeStr string
+	}{
+		{
+			name: "empty",
+			yaml: "",
+			expectedGoCodeStr: "" +
+				"package main\n" +
+				"\n" +
+				"type T any\n",
+		},
+		{
+			name:    "error",
+			yaml:    "\"",
+			wantErr: true,
+		},
+		{
+			name: "bool",
+			yaml: "" +
+				`true`,
+			expectedGoCodeStr: "" +
+				"package main\n" +
+				"\n" +
+				"type T bool\n",
+		},
+		{
+			name: "strings",
+			yaml: "---\n" +
+				"\"a\"\n" +
+				"---\n" +
+				"\"b\"\n",
+			expectedGoCodeStr: "" +
+				"package main\n" +
+				"\n" +
+				"type T string\n",
+		},
+		{
+			name: "object",
+			yaml: "int: 0\n",
+			generatorOptions: []GeneratorOption{
+				WithStructTagName("yaml"),
+			},
+			expectedGoCodeStr: "" +
+				"package main\n" +
+				"\n" +
+				"type T struct {\n" +
+				"\tInt int `yaml:\"int\"`\n" +
+				"}\n",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.skip != "" {
+				t.Skip(tc.skip)
+			}
+			generator := NewGenerator(tc.generatorOptions...)
+			err := generator.ObserveYAMLReader(bytes.NewBufferString(tc.yaml))
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			goCode, err := generator.Generate()
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedGoCodeStr, string(goCode))
+		})
+	}
+}
+
+func TestGenerateInvalidTypeName(t *testing.T) {
+	_, err := NewGenerator(WithTypeName("string")).Generate()
+	assert.EqualError(t, err, "string: invalid type name")
+}
+
+func TestGenerateHelperTypeNameCollision(t *testing.T) {
+	generator := NewGenerator(
+		WithDetectMaps(true),
+		WithTypeName("UUID"),
+		WithTypedMapKeys(true),
+	)
+	assert.NoError(t, generator.ObserveJSONReader(bytes.NewBufferString(`{"f47ac10b-58cc-4372-a567-0e02b2c3d479":1,"0e02b2c3-58cc-4372-a567-f47ac10bd479":2}`)))
+	_, err := generator.Generate()
+	assert.EqualError(t, err, "UUID: type name is used by a helper type")
+}
+
+// unmarshalObservedValuesMain is a program that unmarshals JSON values from
+// stdin into a T and marshals them again.
+const unmarshalObservedValuesMain = `package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+)
+
+func main() {
+	roundTrip := flag.Bool("round-trip", false, "check that values, including the zero value, are marshalled unchanged")
+	flag.Parse()
+	if *roundTrip {
+		var zero T
+		zeroData, err := json.Marshal(zero)
+		if err != nil {
+			panic(err)
+		}
+		if err := json.Unmarshal(zeroData, new(T)); err != nil {
+			panic(fmt.Sprintf("%s: %v", zeroData, err))
+		}
+	}
+	decoder := json.NewDecoder(os.Stdin)
+	for {
+		var data json.RawMessage
+		if err := decoder.Decode(&data); errors.Is(err, io.EOF) {
+			return
+		} else if err != nil {
+			panic(err)
+		}
+		var t T
+		if err := json.Unmarshal(data, &t); err != nil {
+			panic(err)
+		}
+		marshalledData, err := json.Marshal(t)
+		if err != nil {
+			panic(err)
+		}
+		if err := json.Unmarshal(marshalledData, new(T)); err != nil {
+			panic(fmt.Sprintf("%s: %v", marshalledData, err))
+		}
+		if !*roundTrip {
+			continue
+		}
+		var value, marshalledValue any
+		if err := json.Unmarshal(data, &value); err != nil {
+			panic(err)
+		}
+		if err := json.Unmarshal(marshalledData, &marshalledValue); err != nil {
+			panic(err)
+		}
+		if !reflect.DeepEqual(marshalledValue, value) {
+			panic(fmt.Sprintf("%s: marshalled as %s", data, marshalledData))
+		}
+	}
+}
+`
+
+// TestUnmarshalObservedValues tests that the generated code compiles, that the
+// observed values can be unmarshalled into the generated type and marshalled
+// and unmarshalled again, and, if roundTrip is set, that they are marshalled
+// unchanged and that the zero value can be marshalled and unmarshalled.
+func TestUnmarshalObservedValues(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping test that runs go run in short mode")
+	}
+	for _, tc := range []struct {
+		name             string
+		json             string
+		generatorOptions []GeneratorOption
+		roundTrip        bool
+	}{
+		{
+			name: "pointers_never_int_enums",
+			json: `{"p":1}{"p":2}{"p":1}{"p":2}{"p":null}`,
+			generatorOptions: []GeneratorOption{
+				WithEnumValidation(true),
+				WithIntEnums(3),
+				WithPointers(PointersNever),
+			},
+		},
+		{
+			name: "pointers_never_string_enums",
+			json: `{"p":"a"}{"p":"b"}{"p":"a"}{"p":"b"}{"p":null}`,
+			generatorOptions: []GeneratorOption{
+				WithEnumValidation(true),
+				WithPointers(PointersNever),
+				WithStringEnums(3),
+			},
+		},
+		{
+			name: "pointers_never_tuples",
+			json: `{"p":[1,"x"]}{"p":[2,"y"]}{"p":null}`,
+			generatorOptions: []GeneratorOption{
+				WithDetectTuples(true),
+				WithPointers(PointersNever),
+			},
+		},
+		{
+			name: "pointers_never_unions",
+			json: `{"p":{"type":"a","x":1}}{"p":{"type":"b","y":"s"}}{"p":{"type":"a","x":2}}{"p":{"type":"b","y":"t"}}{"p":null}`,
+			generatorOptions: []GeneratorOption{
+				WithDetectUnions(true),
+				WithPointers(PointersNever),
+			},
+		},
+		{
+			name: "nullable_types_sql_time",
+			json: `{"a":"2024-01-01T00:00:00Z","b":null}{"a":null,"b":"2024-01-01T00:00:00Z"}{}`,
+			generatorOptions: []GeneratorOption{
+				WithNullableTypes(NullableTypesSQL),
+				WithPointers(PointersNever),
+			},
+		},
+		{
+			name: "numbers_integral_floats_as_ints",
+			json: `{"count":1.0,"port":8080,"size":null}{"count":2,"port":443.0,"size":4.0}{"count":3.0,"port":80,"size":5}`,
+			generatorOptions: []GeneratorOption{
+				WithIntTypes(IntTypesExact),
+				WithNumbers(NumbersIntegralFloatsAsInts),
+				WithPointers(PointersNever),
+			},
+		},
+		{
+			name: "dates",
+			json: `{"d":"2024-01-31"}{"d":"2024-02-01"}`,
+			generatorOptions: []GeneratorOption{
+				WithTimeLayouts(time.DateOnly),
+			},
+			roundTrip: true,
+		},
+		{
+			name: "iso8601_durations",
+			json: `{"interval":"PT1H30M"}{"interval":"PT5M"}`,
+			generatorOptions: []GeneratorOption{
+				WithDetectISO8601Durations(true),
+			},
+			roundTrip: true,
+		},
+		{
+			name: "int_types_exact_arrays",
+			json: `{"rgb":[255,0,128]}{"rgb":[0,0,0]}`,
+			generatorOptions: []GeneratorOption{
+				WithIntTypes(IntTypesExact),
+			},
+			roundTrip: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			generator := NewGenerator(tc.generatorOptions...)
+			assert.NoError(t, generator.ObserveJSONReader(bytes.NewBufferString(tc.json)))
+			goCode, err := generator.Generate()
+			assert.NoError(t, err)
+			dir := t.TempDir()
+			assert.NoError(t, os.WriteFile(filepath.Join(dir, "main.go"), []byte(unmarshalObservedValuesMain), 0o600))
+			assert.NoError(t, os.WriteFile(filepath.Join(dir, "types.go"), goCode, 0o600))
+			args := []string{"run", "main.go", "types.go"}
+			if tc.roundTrip {
+				args = append(args, "-round-trip")
+			}
+			cmd := exec.CommandContext(t.Context(), "go", args...)
+			cmd.Dir = dir
+			cmd.Stdin = bytes.NewBufferString(tc.json)
+			output, err := cmd.CombinedOutput()
+			assert.NoError(t, err, string(output))
 		})
 	}
 }
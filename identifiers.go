@@ -0,0 +1,133 @@
+package jsonstruct
+
+import (
+	"go/token"
+	"go/types"
+	"strings"
+	"unicode"
+)
+
+// transliterations maps letters from common scripts to their ASCII
+// equivalents.
+var transliterations = map[rune]string{
+	// Latin.
+	'À': "A", 'Á': "A", 'Â': "A", 'Ã': "A", 'Ä': "A", 'Å': "A", 'Ā': "A", 'Ă': "A", 'Ą': "A",
+	'à': "a", 'á': "a", 'â': "a", 'ã': "a", 'ä': "a", 'å': "a", 'ā': "a", 'ă': "a", 'ą': "a",
+	'Æ': "AE", 'æ': "ae",
+	'Ç': "C", 'Ć': "C", 'Č': "C", 'ç': "c", 'ć': "c", 'č': "c",
+	'Ð': "D", 'Ď': "D", 'Đ': "D", 'ð': "d", 'ď': "d", 'đ': "d",
+	'È': "E", 'É': "E", 'Ê': "E", 'Ë': "E", 'Ē': "E", 'Ė': "E", 'Ę': "E", 'Ě': "E",
+	'è': "e", 'é': "e", 'ê': "e", 'ë': "e", 'ē': "e", 'ė': "e", 'ę': "e", 'ě': "e",
+	'Ğ': "G", 'ğ': "g",
+	'Ì': "I", 'Í': "I", 'Î': "I", 'Ï': "I", 'Ī': "I", 'İ': "I",
+	'ì': "i", 'í': "i", 'î': "i", 'ï': "i", 'ī': "i", 'ı': "i",
+	'Ł': "L", 'ł': "l",
+	'Ñ': "N", 'Ń': "N", 'Ň': "N", 'ñ': "n", 'ń': "n", 'ň': "n",
+	'Ò': "O", 'Ó': "O", 'Ô': "O", 'Õ': "O", 'Ö': "O", 'Ø': "O", 'Ō': "O", 'Ő': "O",
+	'ò': "o", 'ó': "o", 'ô': "o", 'õ': "o", 'ö': "o", 'ø': "o", 'ō': "o", 'ő': "o",
+	'Œ': "OE", 'œ': "oe",
+	'Ř': "R", 'ř': "r",
+	'Ś': "S", 'Ş': "S", 'Š': "S", 'ś': "s", 'ş': "s", 'š': "s", 'ß': "ss",
+	'Ț': "T", 'Ť': "T", 'ț': "t", 'ť': "t",
+	'Þ': "TH", 'þ': "th",
+	'Ù': "U", 'Ú': "U", 'Û': "U", 'Ü': "U", 'Ū': "U", 'Ů': "U", 'Ű': "U",
+	'ù': "u", 'ú': "u", 'û': "u", 'ü': "u", 'ū': "u", 'ů': "u", 'ű': "u",
+	'Ý': "Y", 'Ÿ': "Y", 'ý': "y", 'ÿ': "y",
+	'Ź': "Z", 'Ż': "Z", 'Ž': "Z", 'ź': "z", 'ż': "z", 'ž': "z",
+
+	// Greek.
+	'Α': "A", 'Ά': "A", 'Β': "V", 'Γ': "G", 'Δ': "D", 'Ε': "E", 'Έ': "E", 'Ζ': "Z",
+	'Η': "I", 'Ή': "I", 'Θ': "Th", 'Ι': "I", 'Ί': "I", 'Ϊ': "I", 'Κ': "K", 'Λ': "L",
+	'Μ': "M", 'Ν': "N", 'Ξ': "X", 'Ο': "O", 'Ό': "O", 'Π': "P", 'Ρ': "R", 'Σ': "S",
+	'Τ': "T", 'Υ': "Y", 'Ύ': "Y", 'Ϋ': "Y", 'Φ': "F", 'Χ': "Ch", 'Ψ': "Ps", 'Ω': "O",
+	'Ώ': "O",
+	'α': "a", 'ά': "a", 'β': "v", 'γ': "g", 'δ': "d", 'ε': "e", 'έ': "e", 'ζ': "z",
+	'η': "i", 'ή': "i", 'θ': "th", 'ι': "i", 'ί': "i", 'ϊ': "i", 'ΐ': "i", 'κ': "k",
+	'λ': "l", 'μ': "m", 'ν': "n", 'ξ': "x", 'ο': "o", 'ό': "o", 'π': "p", 'ρ': "r",
+	'σ': "s", 'ς': "s", 'τ': "t", 'υ': "y", 'ύ': "y", 'ϋ': "y", 'ΰ': "y", 'φ': "f",
+	'χ': "ch", 'ψ': "ps", 'ω': "o", 'ώ': "o",
+
+	// Cyrillic.
+	'А': "A", 'Б': "B", 'В': "V", 'Г': "G", 'Ґ': "G", 'Д': "D", 'Е': "E", 'Ё': "Yo",
+	'Є': "Ye", 'Ж': "Zh", 'З': "Z", 'И': "I", 'І': "I", 'Ї': "Yi", 'Й': "Y", 'К': "K",
+	'Л': "L", 'М': "M", 'Н': "N", 'О': "O", 'П': "P", 'Р': "R", 'С': "S", 'Т': "T",
+	'У': "U", 'Ў': "U", 'Ф': "F", 'Х': "Kh", 'Ц': "Ts", 'Ч': "Ch", 'Ш': "Sh", 'Щ': "Shch",
+	'Ъ': "", 'Ы': "Y", 'Ь': "", 'Э': "E", 'Ю': "Yu", 'Я': "Ya",
+	'а': "a", 'б': "b", 'в': "v", 'г': "g", 'ґ': "g", 'д': "d", 'е': "e", 'ё': "yo",
+	'є': "ye", 'ж': "zh", 'з': "z", 'и': "i", 'і': "i", 'ї': "yi", 'й': "y", 'к': "k",
+	'л': "l", 'м': "m", 'н': "n", 'о': "o", 'п': "p", 'р': "r", 'с': "s", 'т': "t",
+	'у': "u", 'ў': "u", 'ф': "f", 'х': "kh", 'ц': "ts", 'ч': "ch", 'ш': "sh", 'щ': "shch",
+	'ъ': "", 'ы': "y", 'ь': "", 'э': "e", 'ю': "yu", 'я': "ya",
+}
+
+// transliterate returns s with letters from common scripts replaced by their
+// ASCII equivalents. Other runes are unchanged.
+func transliterate(s string) string {
+	var sb strings.Builder
+	for _, r := range s {
+		if transliteration, ok := transliterations[r]; ok {
+			sb.WriteString(transliteration)
+		} else {
+			sb.WriteRune(r)
+		}
+	}
+	return sb.String()
+}
+
+// isValidExportName returns true if name is a valid exported Go identifier.
+func isValidExportName(name string) bool {
+	return token.IsIdentifier(name) && token.IsExported(name)
+}
+
+// isValidTypeName returns true if name is a valid Go identifier that is not a
+// keyword or a predeclared identifier.
+func isValidTypeName(name string) bool {
+	return token.IsIdentifier(name) && types.Universe.Lookup(name) == nil
+}
+
+// exportName returns the exported name for the value at path. If the exported
+// name of the last property in path is a valid but unexported Go identifier,
+// for example if it was returned by a custom ExportNameFunc, starts with a
+// digit, or consists of letters without case, like CJK, then it is exported by
+// removing any leading underscores and changing its first letter to upper case
+// or, if that is not possible, by adding the prefix "X". Otherwise, for example
+// if the property consists only of symbols, then the name is derived from the
+// exported name of the nearest ancestor with one and the suffix "Property".
+func (options *generateOptions) exportName(path valuePath) string {
+	i := path.propertyIndex()
+	if i == 0 {
+		runes := []rune(path[0])
+		return string(unicode.ToUpper(runes[0])) + string(runes[1:])
+	}
+	if name, ok := options.propertyExportName(path[i]); ok {
+		return name
+	}
+	for j := i - 1; j > 0; j-- {
+		if path[j] == elementsPathComponent {
+			continue
+		}
+		if name, ok := options.propertyExportName(path[j]); ok {
+			return name + "Property"
+		}
+	}
+	return options.exportName(path[:1]) + "Property"
+}
+
+// propertyExportName returns the exported name for property and true, or the
+// empty string and false if no name can be derived from property.
+func (options *generateOptions) propertyExportName(property string) (string, bool) {
+	name := options.exportNameFunc(property)
+	if isValidExportName(name) {
+		return name, true
+	}
+	trimmedName := strings.TrimLeft(name, "_")
+	if !token.IsIdentifier(name) || trimmedName == "" {
+		return "", false
+	}
+	runes := []rune(trimmedName)
+	runes[0] = unicode.ToUpper(runes[0])
+	if upperName := string(runes); isValidExportName(upperName) {
+		return upperName, true
+	}
+	return "X" + trimmedName, true
+}
@@ -0,0 +1,155 @@
+package jsonstruct
+
+import (
+	"encoding/json"
+	"maps"
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/alecthomas/assert/v2"
+)
+
+func TestIsValidExportName(t *testing.T) {
+	expected := map[string]bool{
+		"":      false,
+		"ID":    true,
+		"_":     false,
+		"_123":  false,
+		"___":   false,
+		"id":    false,
+		"日本":    false,
+		"Ünïcø": true,
+		"A-B":   false,
+	}
+	for _, name := range slices.Sorted(maps.Keys(expected)) {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, expected[name], isValidExportName(name))
+		})
+	}
+}
+
+func TestIsValidTypeName(t *testing.T) {
+	expected := map[string]bool{
+		"T":      true,
+		"any":    false,
+		"error":  false,
+		"string": false,
+		"t":      true,
+		"type":   false,
+	}
+	for _, name := range slices.Sorted(maps.Keys(expected)) {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, expected[name], isValidTypeName(name))
+		})
+	}
+}
+
+func TestExportName(t *testing.T) {
+	defaultExportNameFunc := func(name string) string {
+		return DefaultExportNameFunc(name, defaultAbbreviations)
+	}
+	identityExportNameFunc := func(name string) string {
+		return name
+	}
+	for _, tc := range []struct {
+		name           string
+		exportNameFunc ExportNameFunc
+		path           valuePath
+		expected       string
+	}{
+		{
+			name:           "root",
+			exportNameFunc: defaultExportNameFunc,
+			path:           valuePath{"t"},
+			expected:       "T",
+		},
+		{
+			name:           "property",
+			exportNameFunc: defaultExportNameFunc,
+			path:           valuePath{"T", "user_id"},
+			expected:       "UserID",
+		},
+		{
+			name:           "cjk",
+			exportNameFunc: defaultExportNameFunc,
+			path:           valuePath{"T", "名前"},
+			expected:       "X名前",
+		},
+		{
+			name:           "numeric",
+			exportNameFunc: defaultExportNameFunc,
+			path:           valuePath{"T", "1"},
+			expected:       "X1",
+		},
+		{
+			name:           "date",
+			exportNameFunc: defaultExportNameFunc,
+			path:           valuePath{"T", "2024-01-01"},
+			expected:       "X20240101",
+		},
+		{
+			name:           "symbols",
+			exportNameFunc: defaultExportNameFunc,
+			path:           valuePath{"T", "!€"},
+			expected:       "TProperty",
+		},
+		{
+			name:           "nested_symbols",
+			exportNameFunc: defaultExportNameFunc,
+			path:           valuePath{"T", "items", elementsPathComponent, "!", "?"},
+			expected:       "ItemsProperty",
+		},
+		{
+			name:           "empty",
+			exportNameFunc: defaultExportNameFunc,
+			path:           valuePath{"T", ""},
+			expected:       "TProperty",
+		},
+		{
+			name:           "identity",
+			exportNameFunc: identityExportNameFunc,
+			path:           valuePath{"T", "a", "b", "c"},
+			expected:       "C",
+		},
+		{
+			name:           "identity_elements",
+			exportNameFunc: identityExportNameFunc,
+			path:           valuePath{"T", "items", elementsPathComponent},
+			expected:       "Items",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			options := &generateOptions{
+				exportNameFunc: tc.exportNameFunc,
+			}
+			assert.Equal(t, tc.expected, options.exportName(tc.path))
+		})
+	}
+}
+
+func TestExportNameRoundTrip(t *testing.T) {
+	data := `{"1":"a","123e4567-e89b-12d3-a456-426614174000":2,"2024-01-01":true}`
+	var properties map[string]any
+	assert.NoError(t, json.Unmarshal([]byte(data), &properties))
+	options := &generateOptions{
+		exportNameFunc: func(name string) string {
+			return DefaultExportNameFunc(name, defaultAbbreviations)
+		},
+	}
+	keys := slices.Sorted(maps.Keys(properties))
+	fieldNames := options.fieldNames(valuePath{"T"}, keys, nil)
+	fields := make([]reflect.StructField, 0, len(keys))
+	for _, key := range keys {
+		fields = append(fields, reflect.StructField{
+			Name: fieldNames[key].name,
+			Type: reflect.TypeOf(properties[key]),
+			Tag:  reflect.StructTag(`json:"` + key + `"`),
+		})
+	}
+	value := reflect.New(reflect.StructOf(fields)).Interface()
+	assert.NoError(t, json.Unmarshal([]byte(data), value))
+	actual, err := json.Marshal(value)
+	assert.NoError(t, err)
+	assert.Equal(t, data, string(actual))
+}
@@ -0,0 +1,189 @@
+package jsonstruct
+
+import (
+	"encoding/json"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// maxSafeInt is the largest integer that can be represented exactly by a
+// float64, 2^53.
+const maxSafeInt = 1 << 53
+
+// An intRange is a range of integers that fit in a Go integer type.
+type intRange struct {
+	typeStr string
+	min     int64
+	max     int64
+}
+
+// signedIntRanges and unsignedIntRanges are the ranges of Go's sized integer
+// types, smallest first.
+var (
+	signedIntRanges = []intRange{
+		{typeStr: "int8", min: math.MinInt8, max: math.MaxInt8},
+		{typeStr: "int16", min: math.MinInt16, max: math.MaxInt16},
+		{typeStr: "int32", min: math.MinInt32, max: math.MaxInt32},
+		{typeStr: "int64", min: math.MinInt64, max: math.MaxInt64},
+	}
+	unsignedIntRanges = []intRange{
+		{typeStr: "uint8", min: 0, max: math.MaxUint8},
+		{typeStr: "uint16", min: 0, max: math.MaxUint16},
+		{typeStr: "uint32", min: 0, max: math.MaxUint32},
+		{typeStr: "uint64", min: 0, max: math.MaxInt64},
+	}
+)
+
+// observeIntRange records the minimum and maximum observed integer values.
+// It must be called after v.ints or v.integralFloat64s is incremented.
+func (v *value) observeIntRange(i int64, options *observeOptions) {
+	if !options.trackIntRange {
+		return
+	}
+	if v.intRangeObservations() == 1 || i < v.minInt {
+		v.minInt = i
+	}
+	if v.intRangeObservations() == 1 || i > v.maxInt {
+		v.maxInt = i
+	}
+}
+
+// observeLargeUint records an observed integer that is greater than
+// math.MaxInt64. It must be called after v.ints is incremented.
+func (v *value) observeLargeUint(options *observeOptions) {
+	v.largeUints++
+	if !options.trackIntRange {
+		return
+	}
+	v.maxInt = math.MaxInt64
+	if v.intRangeObservations() == 1 {
+		v.minInt = math.MaxInt64
+	}
+	if options.largeInts {
+		v.unsafeInts++
+	}
+}
+
+// intRangeObservations returns the number of values in v's integer range.
+func (v *value) intRangeObservations() int {
+	return v.ints + v.integralFloat64s
+}
+
+// observeUnsafeInt records whether i cannot be represented exactly by a
+// float64.
+func (v *value) observeUnsafeInt(i int64, options *observeOptions) {
+	if options.largeInts && (i > maxSafeInt || i < -maxSafeInt) {
+		v.unsafeInts++
+	}
+}
+
+// observeLargeJSONNumber observes the json.Number n, which is not an int64, as
+// an integer if it is one and returns true. Otherwise it returns false.
+func (v *value) observeLargeJSONNumber(n json.Number, options *observeOptions) bool {
+	if !options.largeInts || !isIntLiteral(string(n)) {
+		return false
+	}
+	v.ints++
+	if _, err := strconv.ParseUint(string(n), 10, 64); err == nil {
+		v.observeLargeUint(options)
+	} else {
+		v.bigInts++
+		v.unsafeInts++
+	}
+	return true
+}
+
+// observeIntString records whether the numeric string s is a negative integer
+// or an integer that does not fit in an int64.
+func (v *value) observeIntString(s string, options *observeOptions) {
+	if !options.largeInts || !isIntLiteral(s) {
+		return
+	}
+	if strings.HasPrefix(s, "-") {
+		v.negativeIntStrings++
+	}
+	if _, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return
+	}
+	if _, err := strconv.ParseUint(s, 10, 64); err == nil {
+		v.largeUintStrings++
+	} else {
+		v.bigIntStrings++
+	}
+}
+
+// hasUnsafeInts returns true if v contains integers that would lose precision
+// if generated as a float64.
+func (v *value) hasUnsafeInts(options *generateOptions) bool {
+	return options.largeInts != LargeIntsFloat64 && v.unsafeInts > 0
+}
+
+// isIntLiteral returns true if s is a JSON number without a fraction or
+// exponent.
+func isIntLiteral(s string) bool {
+	s = strings.TrimPrefix(s, "-")
+	return s != "" && strings.Trim(s, "0123456789") == ""
+}
+
+// largeIntTypeStr returns the Go type of the integer v if it has values that
+// do not fit in an int64, or the empty string otherwise.
+func (v *value) largeIntTypeStr(options *generateOptions) string {
+	switch {
+	case v.bigInts == 0 && v.largeUints == 0:
+		return ""
+	case v.bigInts == 0 && v.minInt >= 0 && v.maxInt == math.MaxInt64:
+		// All integers are non-negative. observeLargeUint only sets v.maxInt
+		// if the integer range is tracked.
+		return "uint64"
+	case options.largeInts == LargeIntsFloat64:
+		// The integers may be negative, or the integer range is not tracked
+		// so their signs are unknown.
+		return "float64"
+	case options.largeInts == LargeIntsBigInt:
+		options.imports["math/big"] = struct{}{}
+		return "*big.Int"
+	default:
+		options.imports["encoding/json"] = struct{}{}
+		return "json.Number"
+	}
+}
+
+// intTypeStr returns the Go type of the integer v, which is located at path,
+// according to options.intTypes.
+func (v *value) intTypeStr(path valuePath, options *generateOptions) string {
+	if largeIntTypeStr := v.largeIntTypeStr(options); largeIntTypeStr != "" {
+		return largeIntTypeStr
+	}
+	if options.intTypes == IntTypesFixed || v.ints == 0 {
+		return options.intType
+	}
+	var intRanges []intRange
+	switch options.intTypes {
+	case IntTypesExact:
+		switch {
+		case v.minInt < 0:
+			intRanges = signedIntRanges
+		case path[len(path)-1] == elementsPathComponent:
+			// encoding/json marshals []uint8 as a base64 string.
+			intRanges = unsignedIntRanges[1:]
+		default:
+			intRanges = unsignedIntRanges
+		}
+	case IntTypesSigned:
+		intRanges = signedIntRanges
+	case IntTypesWiden:
+		if v.minInt >= math.MinInt32 && v.maxInt <= math.MaxInt32 {
+			return options.intType
+		}
+		return "int64"
+	}
+	for _, intRange := range intRanges {
+		if intRange.min <= v.minInt && v.maxInt <= intRange.max {
+			return intRange.typeStr
+		}
+	}
+	// Integers that do not fit in an int64 are handled by largeIntTypeStr, so
+	// all integers fit in an int64.
+	return "int64"
+}
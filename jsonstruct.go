@@ -1,6 +1,7 @@
 package jsonstruct
 
 import (
+	"slices"
 	"strings"
 	"unicode"
 
@@ -28,9 +29,11 @@ var (
 	}
 )
 
-// DefaultExportNameFunc returns the exported name for name.
+// DefaultExportNameFunc returns the exported name for name. Letters from common
+// scripts, like Latin letters with diacritics, Greek, and Cyrillic, are
+// transliterated to ASCII.
 func DefaultExportNameFunc(name string, abbreviations map[string]bool) string {
-	components := SplitComponents(name)
+	components := SplitComponents(transliterate(name))
 	for i, component := range components {
 		componentUpper := strings.ToUpper(component)
 		singularUpper, singularUpperOK := englishSingular(componentUpper)
@@ -57,7 +60,7 @@ func DefaultExportNameFunc(name string, abbreviations map[string]bool) string {
 		}
 	}
 	exportName := string(runes)
-	if !unicode.IsLetter(runes[0]) && runes[0] != '_' {
+	if len(runes) > 0 && !unicode.IsLetter(runes[0]) && runes[0] != '_' {
 		exportName = "_" + exportName
 	}
 	return exportName
@@ -76,6 +79,29 @@ func SplitComponents(name string) []string {
 	}
 }
 
+// hasSuffixComponents returns true if the last components of property, as
+// returned by SplitComponents, match the components of one of suffixes,
+// ignoring case and leading separators.
+func hasSuffixComponents(property string, suffixes []string) bool {
+	components := SplitComponents(property)
+	for i, component := range components {
+		components[i] = strings.ToLower(component)
+	}
+	for _, suffix := range suffixes {
+		suffixComponents := SplitComponents(strings.Trim(suffix, "-_"))
+		if len(suffixComponents) == 0 || len(suffixComponents) > len(components) {
+			continue
+		}
+		for i, component := range suffixComponents {
+			suffixComponents[i] = strings.ToLower(component)
+		}
+		if slices.Equal(components[len(components)-len(suffixComponents):], suffixComponents) {
+			return true
+		}
+	}
+	return false
+}
+
 func englishPlural(nounUpper string) string {
 	if strings.HasSuffix(nounUpper, "S") {
 		return nounUpper + "es"
@@ -83,9 +109,69 @@ func englishPlural(nounUpper string) string {
 	return nounUpper + "s"
 }
 
-func englishSingular(nounUpper string) (string, bool) {
-	if singular, ok := strings.CutSuffix(nounUpper, "SES"); ok {
-		return singular + "S", ok
+// englishSingular returns the singular of noun, which is assumed to be an
+// English noun, and whether noun was plural. The suffix "s" is matched ignoring
+// case. Abbreviations, like "OSES" or "OSes", have their "es" suffix removed.
+func englishSingular(noun string) (string, bool) {
+	switch {
+	case strings.HasSuffix(noun, "SES") || strings.HasSuffix(noun, "Ses"):
+		return noun[:len(noun)-2], true
+	case strings.HasSuffix(noun, "S") || strings.HasSuffix(noun, "s"):
+		return noun[:len(noun)-1], true
+	default:
+		return noun, false
+	}
+}
+
+// singularExportName returns the singular of exportName, which is assumed to
+// be an English noun in PascalCase, and whether exportName was plural.
+func singularExportName(exportName string) (string, bool) {
+	for _, suffix := range []string{"ss", "us", "is"} {
+		if strings.HasSuffix(exportName, suffix) {
+			return exportName, false
+		}
+	}
+	// Nouns ending in "ie", whose plurals end in "ies" like the plurals of
+	// nouns ending in "y".
+	for _, ieNoun := range []string{"Calorie", "Cookie", "Genie", "Hoodie", "Lie", "Pie", "Rookie", "Selfie", "Smoothie", "Tie", "Zombie"} {
+		if strings.HasSuffix(exportName, ieNoun+"s") {
+			return exportName[:len(exportName)-1], true
+		}
+	}
+	// Nouns ending in "s", whose plurals end in "ses" like the plurals of
+	// nouns ending in "se".
+	for _, sNoun := range []string{"Alias", "Atlas", "Bias", "Bonus", "Bus", "Campus", "Canvas", "Census", "Focus", "Gas", "Iris", "Lens", "Status", "Virus"} {
+		if strings.HasSuffix(exportName, sNoun+"es") {
+			return exportName[:len(exportName)-2], true
+		}
+	}
+	// Nouns ending in "ase" or "ise", whose plurals cannot otherwise be
+	// distinguished from the plurals of nouns ending in "as" or "is".
+	for _, seNoun := range []string{"Base", "Case", "Database", "Decrease", "Disease", "Enterprise", "Exercise", "Franchise", "Increase", "Lease", "Noise", "Phase", "Phrase", "Premise", "Promise", "Purchase", "Raise", "Release", "Surprise"} {
+		if strings.HasSuffix(exportName, seNoun+"s") {
+			return exportName[:len(exportName)-1], true
+		}
+	}
+	if strings.HasSuffix(exportName, "ases") || strings.HasSuffix(exportName, "ises") {
+		return exportName, false
+	}
+	for _, suffix := range []struct {
+		plural   string
+		singular string
+	}{
+		{plural: "vies", singular: "vie"},
+		{plural: "ies", singular: "y"},
+		{plural: "sses", singular: "ss"},
+		{plural: "ches", singular: "ch"},
+		{plural: "shes", singular: "sh"},
+		{plural: "xes", singular: "x"},
+	} {
+		if stem, ok := strings.CutSuffix(exportName, suffix.plural); ok && stem != "" {
+			return stem + suffix.singular, true
+		}
+	}
+	if singular, ok := englishSingular(exportName); ok && singular != "" {
+		return singular, true
 	}
-	return strings.CutSuffix(nounUpper, "S")
+	return exportName, false
 }
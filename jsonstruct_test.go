@@ -21,8 +21,13 @@ func TestDefaultExportNameFunc(t *testing.T) {
 		"ids":              "IDs",
 		"urls_to_download": "URLsToDownload",
 		"user_acls":        "UserACLs",
+		"":                 "",
 		"123":              "_123",
 		"A|B":              "A_B",
+		"café":             "Cafe",
+		"straße":           "Strasse",
+		"имя_пользователя": "ImyaPolzovatelya",
+		"όνομα":            "Onoma",
 	}
 	for _, name := range slices.Sorted(maps.Keys(expected)) {
 		t.Run(name, func(t *testing.T) {
@@ -30,3 +35,36 @@ func TestDefaultExportNameFunc(t *testing.T) {
 		})
 	}
 }
+
+func TestSingularExportName(t *testing.T) {
+	expected := map[string]string{
+		"Addresses":  "Address",
+		"Aliases":    "Alias",
+		"Boxes":      "Box",
+		"Cases":      "Case",
+		"Categories": "Category",
+		"Causes":     "Cause",
+		"Cookies":    "Cookie",
+		"Data":       "Data",
+		"Databases":  "Database",
+		"Houses":     "House",
+		"IDs":        "ID",
+		"Items":      "Item",
+		"Licenses":   "License",
+		"Movies":     "Movie",
+		"OSes":       "OS",
+		"Releases":   "Release",
+		"Responses":  "Response",
+		"Status":     "Status",
+		"Statuses":   "Status",
+		"Ties":       "Tie",
+		"Warehouses": "Warehouse",
+		"Watches":    "Watch",
+	}
+	for _, name := range slices.Sorted(maps.Keys(expected)) {
+		t.Run(name, func(t *testing.T) {
+			singular, _ := singularExportName(name)
+			assert.Equal(t, expected[name], singular)
+		})
+	}
+}
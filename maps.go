@@ -0,0 +1,194 @@
+package jsonstruct
+
+import (
+	"maps"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// dynamicKeyRegexps match property names that are likely to be dynamic keys,
+// like IDs, dates, and numbers, rather than field names. Hostnames and IP
+// addresses are also dynamic keys.
+var dynamicKeyRegexps = []*regexp.Regexp{
+	uuidRegexp,
+	regexp.MustCompile(`\A-?[0-9]+(?:\.[0-9]+)?\z`),
+	regexp.MustCompile(`\A[0-9]{4}-[0-9]{2}-[0-9]{2}(?:[T ][0-9:.]+(?:Z|[+-][0-9:]+)?)?\z`),
+}
+
+// uuidRegexp matches UUIDs.
+var uuidRegexp = regexp.MustCompile(`\A[0-9A-Fa-f]{8}-[0-9A-Fa-f]{4}-[0-9A-Fa-f]{4}-[0-9A-Fa-f]{4}-[0-9A-Fa-f]{12}\z`)
+
+// hexKeyRegexp matches lowercase hexadecimal property names.
+var hexKeyRegexp = regexp.MustCompile(`\A[0-9a-f]{6,}\z`)
+
+// hostnameKeyTLDs are well-known top-level domains. Dotted property names, like
+// log.level, are common in configuration files, so only property names with
+// these top-level domains are considered to be hostnames.
+var hostnameKeyTLDs = map[string]bool{
+	"ai":          true,
+	"app":         true,
+	"au":          true,
+	"biz":         true,
+	"br":          true,
+	"ca":          true,
+	"cloud":       true,
+	"cn":          true,
+	"co":          true,
+	"com":         true,
+	"corp":        true,
+	"de":          true,
+	"dev":         true,
+	"edu":         true,
+	"es":          true,
+	"eu":          true,
+	"fr":          true,
+	"gov":         true,
+	"info":        true,
+	"internal":    true,
+	"io":          true,
+	"it":          true,
+	"jp":          true,
+	"kr":          true,
+	"lan":         true,
+	"local":       true,
+	"localdomain": true,
+	"mil":         true,
+	"net":         true,
+	"nl":          true,
+	"org":         true,
+	"ru":          true,
+	"se":          true,
+	"uk":          true,
+	"us":          true,
+}
+
+// isDynamicKey returns true if key looks like a dynamic key.
+func isDynamicKey(key string) bool {
+	if hexKeyRegexp.MatchString(key) && strings.ContainsAny(key, "0123456789") {
+		return true
+	}
+	if isIPAddress(key) || isHostnameKey(key) {
+		return true
+	}
+	for _, dynamicKeyRegexp := range dynamicKeyRegexps {
+		if dynamicKeyRegexp.MatchString(key) {
+			return true
+		}
+	}
+	return false
+}
+
+// isHostnameKey returns true if key is a lowercase hostname with a well-known
+// top-level domain.
+func isHostnameKey(key string) bool {
+	if key != strings.ToLower(key) {
+		return false
+	}
+	match := hostnameRegexp.FindStringSubmatch(key)
+	return match != nil && (hostnameKeyTLDs[match[1]] || strings.HasPrefix(match[1], "xn--"))
+}
+
+// isMap returns true if the object v at path should be generated as a map
+// rather than a struct.
+//
+// An object is a map if it is explicitly overridden by path, or if it has
+// property names containing spaces that cannot otherwise be represented, or if
+// map detection is enabled and all its property values have the same type and
+// either all its property names look like dynamic keys or it has at least
+// options.mapThreshold property names and, on average, each object has at
+// most half of them.
+func (v *value) isMap(path valuePath, options *generateOptions) bool {
+	if isMap, ok := options.mapOverrides[path.String()]; ok {
+		return isMap
+	}
+	if options.unparsableProperties == UnparsablePropertiesComment && !options.skipUnparsableProperties {
+		for property := range v.objectProperties {
+			if strings.ContainsRune(property, ' ') {
+				return true
+			}
+		}
+	}
+	if !options.detectMaps || len(v.objectProperties) < 2 || !v.hasUniformPropertyValues() {
+		return false
+	}
+	allDynamicKeys := true
+	propertyObservations := 0
+	for property, value := range v.objectProperties {
+		if allDynamicKeys && !isDynamicKey(property) {
+			allDynamicKeys = false
+		}
+		propertyObservations += value.observations
+	}
+	if allDynamicKeys {
+		return true
+	}
+	return len(v.objectProperties) >= options.mapThreshold &&
+		2*propertyObservations <= v.objects*len(v.objectProperties)
+}
+
+// hasUniformPropertyValues returns true if all the property values of the
+// object v have the same kind and are compatible with each other.
+func (v *value) hasUniformPropertyValues() bool {
+	allObjectProperties := v.allObjectProperties
+	kinds := 0
+	for _, kind := range allObjectProperties.kinds() {
+		if kind {
+			kinds++
+		}
+	}
+	if kinds != 1 {
+		return false
+	}
+	for _, value := range v.objectProperties {
+		if !value.isCompatible(allObjectProperties) {
+			return false
+		}
+	}
+	return true
+}
+
+// mapKeyType returns the Go type of the keys of the map v. If typed map keys
+// are enabled and every observed key can be unmarshalled into a more specific
+// type then that type is returned.
+func (v *value) mapKeyType(options *generateOptions) string {
+	if !options.typedMapKeys {
+		return "string"
+	}
+	allKeys := func(f func(string) bool) bool {
+		for key := range maps.Keys(v.objectProperties) {
+			if !f(key) {
+				return false
+			}
+		}
+		return true
+	}
+	switch {
+	case allKeys(isInt64Key):
+		return "int64"
+	case allKeys(isTimeKey):
+		options.imports["time"] = struct{}{}
+		return "time.Time"
+	case allKeys(uuidRegexp.MatchString):
+		options.declareHelper("UUID", uuidHelperDecl)
+		return "UUID"
+	default:
+		return "string"
+	}
+}
+
+// isInt64Key returns true if key is the canonical representation of an int64.
+func isInt64Key(key string) bool {
+	i, err := strconv.ParseInt(key, 10, 64)
+	return err == nil && strconv.FormatInt(i, 10) == key
+}
+
+// isTimeKey returns true if key can be unmarshalled into a time.Time.
+func isTimeKey(key string) bool {
+	_, err := time.Parse(time.RFC3339Nano, key)
+	return err == nil
+}
+
+const uuidHelperDecl = `// A UUID is a UUID in its canonical string representation.
+type UUID string`
@@ -0,0 +1,35 @@
+package jsonstruct
+
+import (
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/alecthomas/assert/v2"
+)
+
+func TestIsDynamicKey(t *testing.T) {
+	expected := map[string]bool{
+		"123":                                  true,
+		"2024-01-31":                           true,
+		"192.168.0.1":                          true,
+		"2024-01-31T12:00:00Z":                 true,
+		"3.14":                                 true,
+		"a1b2c3":                               true,
+		"config.yaml":                          false,
+		"deadbeef":                             false,
+		"f47ac10b-58cc-4372-a567-0e02b2c3d479": true,
+		"id":                                   false,
+		"log.level":                            false,
+		"spring.datasource.url":                false,
+		"userName":                             false,
+		"user_name":                            false,
+		"web-1.example.com":                    true,
+		"Web-1.Example.com":                    false,
+	}
+	for _, key := range slices.Sorted(maps.Keys(expected)) {
+		t.Run(key, func(t *testing.T) {
+			assert.Equal(t, expected[key], isDynamicKey(key))
+		})
+	}
+}
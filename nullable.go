@@ -0,0 +1,101 @@
+package jsonstruct
+
+import (
+	"fmt"
+	"strings"
+)
+
+// sqlNullTypes maps Go types to their database/sql Null types and the names of
+// their value fields.
+var sqlNullTypes = map[string]struct {
+	name  string
+	field string
+}{
+	"bool":      {name: "NullBool", field: "Bool"},
+	"float64":   {name: "NullFloat64", field: "Float64"},
+	"int":       {name: "NullInt64", field: "Int64"},
+	"int64":     {name: "NullInt64", field: "Int64"},
+	"string":    {name: "NullString", field: "String"},
+	"time.Time": {name: "NullTime", field: "Time"},
+}
+
+// sqlNullHelperDeclFormat is the format of helper types that embed a
+// database/sql Null type of the same name. Its arguments are the name of the
+// type and the name of its value field.
+const sqlNullHelperDeclFormat = `// A %[1]s is a sql.%[1]s that is marshalled to and unmarshalled from
+// JSON as a plain value or null.
+type %[1]s struct {
+	sql.%[1]s
+}
+
+// MarshalJSON implements encoding/json.Marshaler.
+func (n %[1]s) MarshalJSON() ([]byte, error) {
+	if !n.Valid {
+		return []byte("null"), nil
+	}
+	return json.Marshal(n.%[2]s)
+}
+
+// UnmarshalJSON implements encoding/json.Unmarshaler.
+func (n *%[1]s) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*n = %[1]s{}
+		return nil
+	}
+	if err := json.Unmarshal(data, &n.%[2]s); err != nil {
+		return err
+	}
+	n.Valid = true
+	return nil
+}`
+
+const sqlNullGenericHelperDecl = `// A Null is a sql.Null[T] that is marshalled to and unmarshalled from JSON as
+// a plain value or null.
+type Null[T any] struct {
+	sql.Null[T]
+}
+
+// MarshalJSON implements encoding/json.Marshaler.
+func (n Null[T]) MarshalJSON() ([]byte, error) {
+	if !n.Valid {
+		return []byte("null"), nil
+	}
+	return json.Marshal(n.V)
+}
+
+// UnmarshalJSON implements encoding/json.Unmarshaler.
+func (n *Null[T]) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*n = Null[T]{}
+		return nil
+	}
+	if err := json.Unmarshal(data, &n.V); err != nil {
+		return err
+	}
+	n.Valid = true
+	return nil
+}`
+
+// nullableTypeStr returns a nullable type for typeStr according to
+// options.nullableTypes.
+func (options *generateOptions) nullableTypeStr(typeStr string) string {
+	if options.nullableTypes != NullableTypesSQL || strings.HasPrefix(typeStr, "*") {
+		return pointerTypeStr(typeStr)
+	}
+	options.imports["database/sql"] = struct{}{}
+	options.imports["encoding/json"] = struct{}{}
+	if sqlNullType, ok := sqlNullTypes[typeStr]; ok {
+		options.declareHelper(sqlNullType.name, fmt.Sprintf(sqlNullHelperDeclFormat, sqlNullType.name, sqlNullType.field))
+		return sqlNullType.name
+	}
+	options.declareHelper("Null", sqlNullGenericHelperDecl)
+	return "Null[" + typeStr + "]"
+}
+
+// hasSQLNullType returns true if nullableTypeStr replaces typeStr with a
+// helper type that embeds a database/sql Null type and does not refer to
+// typeStr, like NullTime for time.Time.
+func (options *generateOptions) hasSQLNullType(typeStr string) bool {
+	_, ok := sqlNullTypes[typeStr]
+	return ok && options.nullableTypes == NullableTypesSQL
+}
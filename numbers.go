@@ -0,0 +1,93 @@
+package jsonstruct
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// integralFloatIntHelperDeclFormat is the format of helper types for integers
+// that were observed as integral floats, like 1.0, which encoding/json cannot
+// unmarshal into Go integer types.
+const integralFloatIntHelperDeclFormat = `// %[3]s %[1]s is an integer that can also be unmarshalled from a JSON number
+// with a zero fractional part, like 1.0.
+type %[1]s %[2]s
+
+// UnmarshalJSON implements encoding/json.Unmarshaler.
+func (i *%[1]s) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	var value %[2]s
+	if err := json.Unmarshal(data, &value); err == nil {
+		*i = %[1]s(value)
+		return nil
+	}
+	f, err := strconv.ParseFloat(string(data), 64)
+	if err != nil || f != float64(%[2]s(f)) {
+		return fmt.Errorf("%%s: invalid %[2]s", data)
+	}
+	*i = %[1]s(f)
+	return nil
+}`
+
+// observeIntegralFloat records f if it is an integer that fits in an int64 so
+// that it can later be treated as an integer.
+func (v *value) observeIntegralFloat(f float64, options *observeOptions) {
+	if !options.trackIntegralFloats || f != math.Trunc(f) || f < math.MinInt64 || f >= math.MaxInt64 {
+		return
+	}
+	v.integralFloat64s++
+	i := int64(f)
+	v.observeIntValue(i, options)
+	v.observeIntRange(i, options)
+	v.observeUnsafeInt(i, options)
+}
+
+// normalizeNumbers returns v, which is located at path, with its numbers
+// normalized according to options.numbers or its override for path.
+func (v *value) normalizeNumbers(path valuePath, options *generateOptions) *value {
+	numbers := options.numbers
+	if override, ok := options.numbersOverrides[path.String()]; ok {
+		numbers = override
+	}
+	normalized := *v
+	switch {
+	case numbers == NumbersIntegralFloatsAsInts && v.float64s > 0 && v.float64s == v.integralFloat64s:
+		normalized.ints += normalized.float64s
+		normalized.float64s = 0
+	case numbers == NumbersFloatsAsFloat64 && v.float64s > 0 && v.ints > 0:
+		fallthrough
+	case numbers == NumbersAllFloat64 && v.ints > 0:
+		normalized.float64s += normalized.ints
+		normalized.ints = 0
+	default:
+		return v
+	}
+	return &normalized
+}
+
+// integralFloatIntTypeStr returns the Go type of the integer v, which is
+// located at path and some of which were observed as integral floats, and
+// declares it. Go integer types are wrapped in a helper type that can unmarshal
+// integral floats.
+func (v *value) integralFloatIntTypeStr(path valuePath, options *generateOptions) string {
+	scratch := options.scratch()
+	switch typeStr := v.intTypeStr(path, scratch); typeStr {
+	case "*big.Int":
+		// *big.Int cannot unmarshal integral floats either.
+		options.imports["encoding/json"] = struct{}{}
+		return "json.Number"
+	case "float64", "json.Number":
+		options.commit(scratch)
+		return typeStr
+	default:
+		options.commit(scratch)
+		name := strings.ToUpper(typeStr[:1]) + typeStr[1:]
+		options.imports["encoding/json"] = struct{}{}
+		options.imports["fmt"] = struct{}{}
+		options.imports["strconv"] = struct{}{}
+		options.declareHelper(name, fmt.Sprintf(integralFloatIntHelperDeclFormat, name, typeStr, indefiniteArticle(name)))
+		return name
+	}
+}
@@ -0,0 +1,51 @@
+package jsonstruct
+
+const optionalHelperDecl = `// An Optional is a JSON value that may be absent, null, or a T. It must be
+// used with an ,omitzero tag so that absent values are not marshalled.
+type Optional[T any] struct {
+	Value   T
+	Present bool
+	Null    bool
+}
+
+// IsZero returns true if o is absent.
+func (o Optional[T]) IsZero() bool {
+	return !o.Present
+}
+
+// MarshalJSON implements encoding/json.Marshaler.
+func (o Optional[T]) MarshalJSON() ([]byte, error) {
+	if !o.Present || o.Null {
+		return []byte("null"), nil
+	}
+	return json.Marshal(o.Value)
+}
+
+// UnmarshalJSON implements encoding/json.Unmarshaler.
+func (o *Optional[T]) UnmarshalJSON(data []byte) error {
+	var zero T
+	o.Value = zero
+	o.Present = true
+	o.Null = string(data) == "null"
+	if o.Null {
+		return nil
+	}
+	return json.Unmarshal(data, &o.Value)
+}`
+
+// optionalGoType returns the Go type of v, which is located at path, as an
+// Optional if v was observed to be absent, null, and other values, and
+// options.optionalTypes is set.
+func (v *value) optionalGoType(path valuePath, observations int, options *generateOptions) (goType, bool) {
+	if !options.optionalTypes || v.nulls == 0 || v.nulls == v.observations || v.observations >= observations {
+		return goType{}, false
+	}
+	valueGoType := v.nonNullValue(options).goType(path, 0, options)
+	options.imports["encoding/json"] = struct{}{}
+	options.declareHelper("Optional", optionalHelperDecl)
+	return goType{
+		typeStr:          "Optional[" + valueGoType.typeStr + "]",
+		omitZeroRequired: true,
+		comment:          valueGoType.comment,
+	}, true
+}
@@ -0,0 +1,118 @@
+package jsonstruct
+
+import "strings"
+
+// isNilableTypeStr returns true if the zero value of typeStr is nil.
+func isNilableTypeStr(typeStr string) bool {
+	return typeStr == "any" ||
+		strings.HasPrefix(typeStr, "*") ||
+		strings.HasPrefix(typeStr, "[]") ||
+		strings.HasPrefix(typeStr, "map[")
+}
+
+// nonNullValue returns a copy of v without its observed nulls. The copy is
+// recorded in options.originalValues so that it shares the merged value and
+// recursive descendants of v.
+func (v *value) nonNullValue(options *generateOptions) *value {
+	nonNullValue := *v
+	nonNullValue.observations -= v.nulls
+	nonNullValue.zeros -= v.nulls
+	nonNullValue.nulls = 0
+	if options.originalValues == nil {
+		options.originalValues = make(map[*value]*value)
+	}
+	options.originalValues[&nonNullValue] = options.originalValue(v)
+	return &nonNullValue
+}
+
+// originalValue returns the observed value that v was copied from, or v if v
+// is not a copy.
+func (options *generateOptions) originalValue(v *value) *value {
+	if originalValue, ok := options.originalValues[v]; ok {
+		return originalValue
+	}
+	return v
+}
+
+// pointersGoType returns the Go type of v, which is located at path, according
+// to options.pointers if v was observed to be absent or null.
+func (v *value) pointersGoType(path valuePath, observations int, options *generateOptions) (goType, bool) {
+	if options.pointers == PointersAuto || v.nulls == v.observations {
+		return goType{}, false
+	}
+	absent := observations > 0 && v.observations < observations
+	null := v.nulls > 0
+	switch {
+	case !absent && !null:
+		return goType{}, false
+	case v.objects > 0 && v.discriminator(options) != "":
+		// Discriminated unions reject null, so they use pointers as with
+		// PointersAuto.
+		return goType{}, false
+	}
+
+	// If the Go type of the non-null value is replaced by a database/sql Null
+	// type then its imports, like time for time.Time, are not needed.
+	nonNullValue := v.nonNullValue(options)
+	valueOptions := options.scratch()
+	valueGoType := nonNullValue.goType(path, 0, valueOptions)
+	if !null || !options.hasSQLNullType(valueGoType.typeStr) {
+		options.commit(valueOptions)
+	}
+
+	// Types whose zero value is nil never need pointers.
+	if isNilableTypeStr(valueGoType.typeStr) {
+		if options.pointers == PointersNever {
+			return goType{
+				typeStr:          valueGoType.typeStr,
+				omitZeroRequired: absent,
+				comment:          valueGoType.comment,
+			}, true
+		}
+		return goType{
+			typeStr:   valueGoType.typeStr,
+			omitEmpty: absent && v.empties == 0,
+			omitZero:  absent,
+			comment:   valueGoType.comment,
+		}, true
+	}
+
+	switch {
+	case null && (options.pointers != PointersNever || options.nullableTypes == NullableTypesSQL):
+		typeStr := options.nullableTypeStr(valueGoType.typeStr)
+		// ,omitempty has no effect on nullable struct types, like NullTime,
+		// so absent values need ,omitzero.
+		return goType{
+			typeStr:          typeStr,
+			omitEmpty:        absent,
+			omitZero:         absent,
+			omitZeroRequired: absent && !strings.HasPrefix(typeStr, "*"),
+			stringTag:        valueGoType.stringTag,
+			comment:          valueGoType.comment,
+		}, true
+	case options.pointers == PointersAbsentOrNull:
+		return goType{
+			typeStr:   "*" + valueGoType.typeStr,
+			omitEmpty: true,
+			omitZero:  true,
+			stringTag: valueGoType.stringTag,
+			comment:   valueGoType.comment,
+		}, true
+	case options.pointers == PointersNever:
+		return goType{
+			typeStr:          valueGoType.typeStr,
+			omitZeroRequired: true,
+			stringTag:        valueGoType.stringTag,
+			comment:          valueGoType.comment,
+		}, true
+	default:
+		// Absent values are omitted unless they would be confused with
+		// observed zero values.
+		return goType{
+			typeStr:          valueGoType.typeStr,
+			omitZeroRequired: v.empties == 0 && nonNullValue.zeros == 0,
+			stringTag:        valueGoType.stringTag,
+			comment:          valueGoType.comment,
+		}, true
+	}
+}
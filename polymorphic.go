@@ -0,0 +1,112 @@
+package jsonstruct
+
+const stringOrNumberHelperDecl = `// A StringOrNumber is a JSON value that is either a string or a number.
+type StringOrNumber struct {
+	Value    string
+	IsNumber bool
+}
+
+// MarshalJSON implements encoding/json.Marshaler.
+func (s StringOrNumber) MarshalJSON() ([]byte, error) {
+	if s.IsNumber {
+		return json.Marshal(json.Number(s.Value))
+	}
+	return json.Marshal(s.Value)
+}
+
+// UnmarshalJSON implements encoding/json.Unmarshaler.
+func (s *StringOrNumber) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	if len(data) > 0 && data[0] == '"' {
+		s.IsNumber = false
+		return json.Unmarshal(data, &s.Value)
+	}
+	var number json.Number
+	if err := json.Unmarshal(data, &number); err != nil {
+		return err
+	}
+	s.Value = number.String()
+	s.IsNumber = true
+	return nil
+}`
+
+const oneOrManyHelperDecl = `// A OneOrMany is a JSON value that is either a single T or an array of T. It
+// is always marshalled as an array.
+type OneOrMany[T any] []T
+
+// MarshalJSON implements encoding/json.Marshaler.
+func (o OneOrMany[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal([]T(o))
+}
+
+// UnmarshalJSON implements encoding/json.Unmarshaler.
+func (o *OneOrMany[T]) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*o = nil
+		return nil
+	}
+	if len(data) > 0 && data[0] == '[' {
+		return json.Unmarshal(data, (*[]T)(o))
+	}
+	var one T
+	if err := json.Unmarshal(data, &one); err != nil {
+		return err
+	}
+	*o = OneOrMany[T]{one}
+	return nil
+}`
+
+// polymorphicGoType returns the Go type of v, which is located at path, if v
+// is a mixture of types that can be represented by a helper type.
+func (v *value) polymorphicGoType(path valuePath, observations int, options *generateOptions) (goType, bool) {
+	switch {
+	case v.strings > 0 && v.float64s+v.ints > 0 && v.arrays+v.bools+v.objects == 0:
+		options.imports["encoding/json"] = struct{}{}
+		options.declareHelper("StringOrNumber", stringOrNumberHelperDecl)
+		if v.nulls > 0 {
+			return goType{
+				typeStr: "*StringOrNumber",
+			}, true
+		}
+		return goType{
+			typeStr:   "StringOrNumber",
+			omitEmpty: v.strings+v.float64s+v.ints < observations,
+		}, true
+	case v.arrays > 0 && v.arrayElements != nil && v.arrayElements.arrays == 0 && v.isOneOrMany():
+		// Generate the element type from the single values and the array
+		// elements combined. Nulls are unmarshalled as nil slices, not as
+		// elements.
+		elementValue := v.merge(v.arrayElements)
+		elementValue.observations -= v.arrays + v.nulls
+		elementValue.nulls -= v.nulls
+		elementValue.zeros -= v.nulls
+		elementValue.arrays = 0
+		elementValue.arrayElements = nil
+		elementGoType := elementValue.goType(path.appendElements(), 0, options)
+		options.imports["encoding/json"] = struct{}{}
+		options.declareHelper("OneOrMany", oneOrManyHelperDecl)
+		return goType{
+			typeStr:   "OneOrMany[" + elementGoType.typeStr + "]",
+			omitEmpty: v.arrays+v.bools+v.float64s+v.ints+v.nulls+v.objects+v.strings < observations && v.empties == 0,
+		}, true
+	default:
+		return goType{}, false
+	}
+}
+
+// isOneOrMany returns true if v is a mixture of arrays and single values of
+// the same kind as the array elements.
+func (v *value) isOneOrMany() bool {
+	singleKinds, elementKinds := v.kinds(), v.arrayElements.kinds()
+	// The arrays are the many values, so they are not single values.
+	singleKinds[0] = false
+	kindCount := 0
+	for _, kind := range singleKinds {
+		if kind {
+			kindCount++
+		}
+	}
+	return kindCount == 1 && (elementKinds == singleKinds || elementKinds == [5]bool{})
+}
@@ -0,0 +1,154 @@
+package jsonstruct
+
+import (
+	"maps"
+	"slices"
+)
+
+// A recursiveGroup is an object and its recursive descendants.
+type recursiveGroup struct {
+	descendants   []*value
+	relativePaths []string
+}
+
+// A recursiveType is a self-referential type that is being generated.
+type recursiveType struct {
+	path          valuePath
+	relativePaths []string
+	name          string
+}
+
+// findRecursiveTypes finds objects in v, which is located at path, that are
+// recursive descendants of one of their ancestors and records them in
+// options.recursiveGroups, keyed by their outermost recursive ancestor.
+//
+// An object is a recursive descendant of its nearest ancestor that overlaps
+// with it if the object is located at the same relative path from its ancestor
+// that it has itself, like the children of a node in a tree, or that its
+// ancestor is located at from its own ancestor, like the leaves of a tree.
+// This distinguishes recursive descendants from sibling objects that happen to
+// have similar properties.
+func (options *generateOptions) findRecursiveTypes(v *value, path valuePath) {
+	options.recursiveGroups = make(map[*value]*recursiveGroup)
+	recursiveRoots := make(map[*value]*value)
+	relativePaths := make(map[*value]string)
+	type ancestor struct {
+		value *value
+		path  valuePath
+	}
+	var visit func(*value, valuePath, []ancestor)
+	visit = func(v *value, path valuePath, ancestors []ancestor) {
+		if v == nil {
+			return
+		}
+		if v.objects > 0 && v.isMap(path, options) {
+			visit(v.allObjectProperties, path.appendElements(), ancestors)
+		} else if v.objects > 0 && len(v.objectProperties) > 0 && v.discriminator(options) == "" {
+			for _, ancestor := range slices.Backward(ancestors) {
+				if !ancestor.value.overlaps(v) {
+					continue
+				}
+				relativePath := path[len(ancestor.path):]
+				relativePathStr := relativePath.String()
+				if _, ok := v.objectProperties[relativePath[0]]; !ok && relativePaths[ancestor.value] != relativePathStr {
+					continue
+				}
+				root := ancestor.value
+				if ancestorRoot, ok := recursiveRoots[ancestor.value]; ok {
+					root = ancestorRoot
+				}
+				recursiveRoots[v] = root
+				relativePaths[v] = relativePathStr
+				group, ok := options.recursiveGroups[root]
+				if !ok {
+					group = &recursiveGroup{}
+					options.recursiveGroups[root] = group
+				}
+				group.descendants = append(group.descendants, v)
+				if !slices.Contains(group.relativePaths, relativePathStr) {
+					group.relativePaths = append(group.relativePaths, relativePathStr)
+				}
+				break
+			}
+			ancestors = append(slices.Clip(ancestors), ancestor{
+				value: v,
+				path:  path,
+			})
+			for _, property := range slices.Sorted(maps.Keys(v.objectProperties)) {
+				visit(v.objectProperties[property], path.appendProperty(property), ancestors)
+			}
+		}
+		visit(v.arrayElements, path.appendElements(), ancestors)
+	}
+	visit(v, path, nil)
+}
+
+// overlaps returns true if v and other have the same kinds and, if both are
+// objects with properties, at least one common property, and all common
+// properties overlap. Unlike isCompatible, other may have only a few of the
+// properties of v, as the deepest recursive descendants often do.
+func (v *value) overlaps(other *value) bool {
+	if v == nil || other == nil {
+		return true
+	}
+	vKinds, otherKinds := v.kinds(), other.kinds()
+	switch {
+	case vKinds == [5]bool{} || otherKinds == [5]bool{}:
+		return true
+	case vKinds != otherKinds:
+		return false
+	case !v.arrayElements.overlaps(other.arrayElements):
+		return false
+	case len(v.objectProperties) == 0 || len(other.objectProperties) == 0:
+		return true
+	}
+	commonProperties := 0
+	for property, value := range v.objectProperties {
+		if otherValue, ok := other.objectProperties[property]; ok {
+			if !value.overlaps(otherValue) {
+				return false
+			}
+			commonProperties++
+		}
+	}
+	return commonProperties > 0
+}
+
+// recursiveTypeRef returns a pointer to the recursive type being generated
+// that the object located at path refers to, if any.
+func (options *generateOptions) recursiveTypeRef(path valuePath) (string, bool) {
+	for _, recursiveType := range options.recursiveTypes {
+		if len(path) <= len(recursiveType.path) || !slices.Equal(path[:len(recursiveType.path)], recursiveType.path) {
+			continue
+		}
+		if slices.Contains(recursiveType.relativePaths, path[len(recursiveType.path):].String()) {
+			return "*" + recursiveType.name, true
+		}
+	}
+	return "", false
+}
+
+// recursiveTypeStr returns the Go type of the object v, which is located at
+// path, if it is the outermost of a group of recursive objects, or the empty
+// string otherwise. The type is generated from v and all of its recursive
+// descendants combined, and descendants refer to it by pointer. At the root,
+// the recursive type is the generated type itself.
+func (v *value) recursiveTypeStr(path valuePath, options *generateOptions) string {
+	group, ok := options.recursiveGroups[options.originalValue(v)]
+	if !ok {
+		return ""
+	}
+	mergedValue := v
+	for _, descendant := range group.descendants {
+		mergedValue = mergedValue.merge(descendant)
+	}
+	name := options.reserveTypeName(path)
+	options.recursiveTypes = append(options.recursiveTypes, recursiveType{
+		path:          path,
+		relativePaths: group.relativePaths,
+		name:          name,
+	})
+	typeStr := mergedValue.structTypeStr(path, options)
+	options.recursiveTypes = options.recursiveTypes[:len(options.recursiveTypes)-1]
+	return options.declareReservedType(path, name, typeStr, "")
+}
@@ -0,0 +1,145 @@
+package jsonstruct
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// timeLayoutTypeNames are the names of the types generated for well-known time
+// layouts.
+var timeLayoutTypeNames = map[string]string{
+	time.ANSIC:            "ANSICTime",
+	time.DateOnly:         "Date",
+	time.DateTime:         "DateTime",
+	time.RFC1123:          "RFC1123Time",
+	time.RFC1123Z:         "RFC1123ZTime",
+	time.RFC822:           "RFC822Time",
+	time.RFC822Z:          "RFC822ZTime",
+	time.RFC850:           "RFC850Time",
+	time.RubyDate:         "RubyDateTime",
+	time.UnixDate:         "UnixDateTime",
+	"2006-01-02T15:04:05": "LocalDateTime",
+}
+
+const dateHelperDecl = `// A Date is a date without a time or time zone.
+type Date struct {
+	Year  int
+	Month time.Month
+	Day   int
+}
+
+// String returns d in the format 2006-01-02.
+func (d Date) String() string {
+	return fmt.Sprintf("%04d-%02d-%02d", d.Year, d.Month, d.Day)
+}
+
+// MarshalJSON implements encoding/json.Marshaler. The zero Date is marshalled
+// as null.
+func (d Date) MarshalJSON() ([]byte, error) {
+	if d == (Date{}) {
+		return []byte("null"), nil
+	}
+	return json.Marshal(d.String())
+}
+
+// UnmarshalJSON implements encoding/json.Unmarshaler.
+func (d *Date) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	t, err := time.Parse(time.DateOnly, s)
+	if err != nil {
+		return err
+	}
+	d.Year, d.Month, d.Day = t.Date()
+	return nil
+}`
+
+const timeLayoutHelperDeclFormat = `// %[3]s %[1]s is a time.Time that is marshalled with the layout %[2]q.
+type %[1]s struct {
+	time.Time
+}
+
+// MarshalJSON implements encoding/json.Marshaler.
+func (t %[1]s) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.Format(%[2]q))
+}
+
+// UnmarshalJSON implements encoding/json.Unmarshaler.
+func (t *%[1]s) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	parsed, err := time.Parse(%[2]q, s)
+	if err != nil {
+		return err
+	}
+	t.Time = parsed
+	return nil
+}`
+
+// indefiniteArticle returns the indefinite article for name, "An" if name
+// starts with a vowel sound and "A" otherwise. Names that start with an
+// initialism, like RFC1123Time, are read letter by letter.
+func indefiniteArticle(name string) string {
+	first, size := utf8.DecodeRuneInString(name)
+	second, _ := utf8.DecodeRuneInString(name[size:])
+	vowelSounds := "AEIO"
+	if unicode.IsUpper(second) {
+		vowelSounds = "AEFHILMNORSX"
+	}
+	if strings.ContainsRune(vowelSounds, first) {
+		return "An"
+	}
+	return "A"
+}
+
+// observeTimeLayouts records which of options.timeLayouts s matches.
+func (v *value) observeTimeLayouts(s string, options *observeOptions) {
+	for _, layout := range options.timeLayouts {
+		if _, err := time.Parse(layout, s); err == nil {
+			if v.timeLayouts == nil {
+				v.timeLayouts = make(map[string]int)
+			}
+			v.timeLayouts[layout]++
+		}
+	}
+}
+
+// timeLayoutTypeStr returns the Go type of the string v if all observed strings
+// match the same time layout, and declares it. It returns the empty string if
+// there is no such layout. Earlier layouts in options.timeLayouts take
+// precedence.
+func (v *value) timeLayoutTypeStr(options *generateOptions) string {
+	for i, layout := range options.timeLayouts {
+		if v.timeLayouts[layout] != v.strings {
+			continue
+		}
+		options.imports["encoding/json"] = struct{}{}
+		options.imports["time"] = struct{}{}
+		if layout == time.DateOnly {
+			options.imports["fmt"] = struct{}{}
+			options.declareHelper("Date", dateHelperDecl)
+			return "Date"
+		}
+		name, ok := timeLayoutTypeNames[layout]
+		if !ok {
+			name = "Time" + strconv.Itoa(i+1)
+		}
+		options.declareHelper(name, fmt.Sprintf(timeLayoutHelperDeclFormat, name, layout, indefiniteArticle(name)))
+		return name
+	}
+	return ""
+}
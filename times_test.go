@@ -0,0 +1,27 @@
+package jsonstruct
+
+import (
+	"testing"
+
+	"github.com/alecthomas/assert/v2"
+)
+
+func TestIndefiniteArticle(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		expected string
+	}{
+		{name: "ANSICTime", expected: "An"},
+		{name: "DateTime", expected: "A"},
+		{name: "LocalDateTime", expected: "A"},
+		{name: "RFC1123Time", expected: "An"},
+		{name: "RFC3339NanoTime", expected: "An"},
+		{name: "RubyDateTime", expected: "A"},
+		{name: "Time1", expected: "A"},
+		{name: "UnixDateTime", expected: "A"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, indefiniteArticle(tc.name))
+		})
+	}
+}
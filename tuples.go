@@ -0,0 +1,197 @@
+package jsonstruct
+
+import (
+	"bytes"
+	"fmt"
+	"slices"
+	"strconv"
+)
+
+// Arrays are only fixed-length if they have between minTupleLength and
+// maxTupleLength elements. Arrays whose elements have the same type at every
+// position are only fixed-length if at least minFixedLengthArrays arrays are
+// observed, as a few arrays with the same length may be a coincidence.
+const (
+	minTupleLength       = 2
+	maxTupleLength       = 8
+	minFixedLengthArrays = 3
+)
+
+// observeArrayLength records the length of the array a and, while all arrays
+// have the same length, the values at each position.
+func (v *value) observeArrayLength(a []any, options *observeOptions) {
+	switch {
+	case !options.trackArrayLengths || v.variableArrayLengths:
+		return
+	case v.arrays == 1:
+		v.arrayLength = len(a)
+	case len(a) != v.arrayLength:
+		v.variableArrayLengths = true
+		v.tupleElements = nil
+		return
+	}
+	if len(a) > maxTupleLength {
+		// Long arrays are treated as variable-length.
+		v.variableArrayLengths = true
+		return
+	}
+	if v.tupleElements == nil {
+		v.tupleElements = make([]*value, len(a))
+	}
+	for i, e := range a {
+		v.tupleElements[i] = v.tupleElements[i].observe(e, options)
+	}
+}
+
+// mergeArrayLengths sets the array lengths and tuple elements of merged, which
+// combines v and other.
+func (v *value) mergeArrayLengths(other, merged *value) {
+	switch {
+	case v.arrays == 0:
+		merged.arrayLength, merged.variableArrayLengths = other.arrayLength, other.variableArrayLengths
+		merged.tupleElements = mergeTupleElements(other.tupleElements, nil)
+	case other.arrays == 0:
+		merged.arrayLength, merged.variableArrayLengths = v.arrayLength, v.variableArrayLengths
+		merged.tupleElements = mergeTupleElements(v.tupleElements, nil)
+	case v.variableArrayLengths || other.variableArrayLengths || v.arrayLength != other.arrayLength:
+		merged.variableArrayLengths = true
+	default:
+		merged.arrayLength = v.arrayLength
+		merged.tupleElements = mergeTupleElements(v.tupleElements, other.tupleElements)
+	}
+}
+
+// mergeTupleElements returns the merged tuple elements of a and b, which must
+// have the same length if both are non-nil.
+func mergeTupleElements(a, b []*value) []*value {
+	if a == nil && b == nil {
+		return nil
+	}
+	merged := make([]*value, max(len(a), len(b)))
+	for i := range merged {
+		var aElement, bElement *value
+		if a != nil {
+			aElement = a[i]
+		}
+		if b != nil {
+			bElement = b[i]
+		}
+		merged[i] = aElement.merge(bElement)
+	}
+	return merged
+}
+
+// isFixedLength returns true if the array v should be generated as a
+// fixed-length array or a tuple.
+func (v *value) isFixedLength(options *generateOptions) bool {
+	return options.detectTuples &&
+		v.arrays >= 2 &&
+		!v.variableArrayLengths &&
+		minTupleLength <= v.arrayLength && v.arrayLength <= maxTupleLength &&
+		len(v.tupleElements) == v.arrayLength
+}
+
+// fixedLengthTypeStr returns the Go type of the fixed-length array v, which is
+// located at path, or the empty string if v is not fixed-length. Arrays whose
+// elements have the same type at every position are generated as [N]T. Arrays
+// whose elements have different types at each position are generated as a
+// tuple struct.
+func (v *value) fixedLengthTypeStr(path valuePath, options *generateOptions) string {
+	if !v.isFixedLength(options) {
+		return ""
+	}
+	// Whether v is fixed-length depends on the types of its elements, so
+	// generate them in scratch options that are only committed if it is.
+	scratch := options.scratch()
+	elementTypeStrs := make([]string, 0, len(v.tupleElements))
+	for _, tupleElement := range v.tupleElements {
+		elementGoType := tupleElement.goType(path.appendElements(), 0, scratch)
+		elementTypeStrs = append(elementTypeStrs, elementGoType.typeStr)
+	}
+	switch {
+	case allEqual(elementTypeStrs) && v.arrays < minFixedLengthArrays:
+		return ""
+	case allEqual(elementTypeStrs):
+		options.commit(scratch)
+		return "[" + strconv.Itoa(len(elementTypeStrs)) + "]" + elementTypeStrs[0]
+	case !slices.Contains(elementTypeStrs, "any"):
+		options.commit(scratch)
+		return options.declareTuple(path, elementTypeStrs)
+	default:
+		return ""
+	}
+}
+
+// allEqual returns true if all elements of ss are equal.
+func allEqual(ss []string) bool {
+	for _, s := range ss[1:] {
+		if s != ss[0] {
+			return false
+		}
+	}
+	return true
+}
+
+// declareTuple declares a tuple struct with fields of types elementTypeStrs
+// for the array at path and returns its Go type. The tuple struct implements
+// encoding/json.Marshaler and encoding/json.Unmarshaler by marshalling its
+// fields as an array, and, like encoding/json, unmarshals null as a no-op. At
+// the root, the tuple struct is the generated type itself.
+func (options *generateOptions) declareTuple(path valuePath, elementTypeStrs []string) string {
+	fieldName := func(i int) string {
+		return "V" + strconv.Itoa(i)
+	}
+	structBuffer := &bytes.Buffer{}
+	fmt.Fprintf(structBuffer, "struct {\n")
+	for i, elementTypeStr := range elementTypeStrs {
+		fmt.Fprintf(structBuffer, "%s %s\n", fieldName(i), elementTypeStr)
+	}
+	fmt.Fprintf(structBuffer, "}")
+	typeStr := structBuffer.String()
+
+	// Tuple structs are declared like other types so that identical tuples
+	// share a type.
+	name := path[0]
+	if len(path) > 1 {
+		name = options.declareType(path, typeStr)
+	}
+
+	options.imports["encoding/json"] = struct{}{}
+	options.imports["fmt"] = struct{}{}
+	b := &bytes.Buffer{}
+	fmt.Fprintf(b, "// UnmarshalJSON implements encoding/json.Unmarshaler.\n")
+	fmt.Fprintf(b, "func (t *%s) UnmarshalJSON(data []byte) error {\n", name)
+	fmt.Fprintf(b, "if string(data) == \"null\" {\n")
+	fmt.Fprintf(b, "return nil\n")
+	fmt.Fprintf(b, "}\n")
+	fmt.Fprintf(b, "var elements []json.RawMessage\n")
+	fmt.Fprintf(b, "if err := json.Unmarshal(data, &elements); err != nil {\n")
+	fmt.Fprintf(b, "return err\n")
+	fmt.Fprintf(b, "}\n")
+	fmt.Fprintf(b, "if len(elements) != %d {\n", len(elementTypeStrs))
+	fmt.Fprintf(b, "return fmt.Errorf(\"%%d: invalid number of %s elements\", len(elements))\n", name)
+	fmt.Fprintf(b, "}\n")
+	for i := range elementTypeStrs {
+		fmt.Fprintf(b, "if err := json.Unmarshal(elements[%d], &t.%s); err != nil {\n", i, fieldName(i))
+		fmt.Fprintf(b, "return err\n")
+		fmt.Fprintf(b, "}\n")
+	}
+	fmt.Fprintf(b, "return nil\n")
+	fmt.Fprintf(b, "}\n")
+	fmt.Fprintf(b, "\n// MarshalJSON implements encoding/json.Marshaler.\n")
+	fmt.Fprintf(b, "func (t %s) MarshalJSON() ([]byte, error) {\n", name)
+	fmt.Fprintf(b, "return json.Marshal([]any{")
+	for i := range elementTypeStrs {
+		if i > 0 {
+			fmt.Fprintf(b, ", ")
+		}
+		fmt.Fprintf(b, "t.%s", fieldName(i))
+	}
+	fmt.Fprintf(b, "})\n")
+	fmt.Fprintf(b, "}")
+	options.declareMethods(name, b.String())
+	if len(path) == 1 {
+		return typeStr
+	}
+	return name
+}
@@ -0,0 +1,254 @@
+package jsonstruct
+
+import (
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// elementsPathComponent is the path component for array elements and map
+// values.
+const elementsPathComponent = "[]"
+
+// A valuePath is the location of a value in the observed values. The first
+// component is the type name and subsequent components are property names or
+// elementsPathComponent.
+type valuePath []string
+
+// appendElements returns a new valuePath for the elements of the array or map
+// at p.
+func (p valuePath) appendElements() valuePath {
+	return append(slices.Clip(p), elementsPathComponent)
+}
+
+// appendProperty returns a new valuePath for property of the object at p.
+func (p valuePath) appendProperty(property string) valuePath {
+	return append(slices.Clip(p), property)
+}
+
+// String returns p as a string, for example "T.items[].id".
+func (p valuePath) String() string {
+	var sb strings.Builder
+	for i, component := range p {
+		if i > 0 && component != elementsPathComponent {
+			sb.WriteByte('.')
+		}
+		sb.WriteString(component)
+	}
+	return sb.String()
+}
+
+// propertyIndex returns the index of the last property in p, ignoring any
+// trailing elements components, or zero if there is no such property.
+func (p valuePath) propertyIndex() int {
+	for i := len(p) - 1; i > 0; i-- {
+		if p[i] != elementsPathComponent {
+			return i
+		}
+	}
+	return 0
+}
+
+// typeName returns the preferred type name for the value at p.
+func (options *generateOptions) typeName(p valuePath) string {
+	i := p.propertyIndex()
+	name := p[0]
+	if i > 0 {
+		name = options.exportName(p[:i+1])
+	}
+	if i == len(p)-1 {
+		return name
+	}
+	if singular, ok := singularExportName(name); ok {
+		return singular
+	}
+	return name + "Element"
+}
+
+// declareType declares a named type for typeStr at p and returns its name.
+func (options *generateOptions) declareType(p valuePath, typeStr string) string {
+	if name, ok := options.typeNamesByTypeStr[typeStr]; ok && options.deduplicateTypes != DeduplicateTypesNever {
+		return name
+	}
+	name := options.newTypeName(p)
+	options.typeDecls[name] = typeStr
+	if _, ok := options.typeNamesByTypeStr[typeStr]; !ok {
+		options.typeNamesByTypeStr[typeStr] = name
+	}
+	return name
+}
+
+// newTypeName returns a new, unused type name for p. The preferred name is
+// derived from p. If that name is already taken then the name of the parent
+// type is used as a prefix and, if that is also taken, a numeric suffix is
+// added.
+func (options *generateOptions) newTypeName(p valuePath) string {
+	if options.typeDecls == nil {
+		options.typeDecls = make(map[string]string)
+		options.typeNamesByTypeStr = make(map[string]string)
+	}
+	name := options.typeName(p)
+	if !options.isTypeNameAvailable(p, name) {
+		if i := p.propertyIndex(); i > 0 {
+			name = options.typeName(p[:i]) + name
+		}
+		for base, n := name, 2; !options.isTypeNameAvailable(p, name); n++ {
+			name = base + strconv.Itoa(n)
+		}
+	}
+	return name
+}
+
+// isTypeNameAvailable returns true if name is not already used by the root
+// type of p, a declared type, or a helper type, and is not reserved for a
+// helper type.
+func (options *generateOptions) isTypeNameAvailable(p valuePath, name string) bool {
+	_, isTypeDecl := options.typeDecls[name]
+	_, isHelperDecl := options.helperDecls[name]
+	_, isReserved := options.reservedTypeNames[name]
+	return !isTypeDecl && !isHelperDecl && !isReserved && name != p[0]
+}
+
+// mergeCompatibleObjects finds groups of compatible objects in v and records
+// the merged value of each group in options.mergedValues. Objects are never
+// merged with their ancestors.
+func (options *generateOptions) mergeCompatibleObjects(v *value, path valuePath) {
+	type objectGroup struct {
+		members     []*value
+		mergedValue *value
+	}
+	var objectGroups []*objectGroup
+	var visit func(*value, valuePath, []*value)
+	visit = func(v *value, path valuePath, ancestors []*value) {
+		if v == nil {
+			return
+		}
+		if v.objects > 0 && v.isMap(path, options) {
+			visit(v.allObjectProperties, path.appendElements(), ancestors)
+		} else if v.objects > 0 && len(v.objectProperties) > 0 && v.discriminator(options) == "" {
+			var group *objectGroup
+			for _, objectGroup := range objectGroups {
+				if slices.ContainsFunc(objectGroup.members, func(member *value) bool {
+					return slices.Contains(ancestors, member)
+				}) {
+					continue
+				}
+				if objectGroup.mergedValue.isCompatible(v) {
+					group = objectGroup
+					break
+				}
+			}
+			if group == nil {
+				group = &objectGroup{}
+				objectGroups = append(objectGroups, group)
+			}
+			group.members = append(group.members, v)
+			group.mergedValue = group.mergedValue.merge(v)
+			ancestors = append(slices.Clip(ancestors), v)
+			for _, property := range slices.Sorted(maps.Keys(v.objectProperties)) {
+				visit(v.objectProperties[property], path.appendProperty(property), ancestors)
+			}
+		}
+		visit(v.arrayElements, path.appendElements(), ancestors)
+	}
+	visit(v, path, nil)
+
+	options.mergedValues = make(map[*value]*value)
+	for _, objectGroup := range objectGroups {
+		if len(objectGroup.members) < 2 {
+			continue
+		}
+		for _, member := range objectGroup.members {
+			options.mergedValues[member] = objectGroup.mergedValue
+		}
+	}
+}
+
+// declareHelper declares a helper type called name with the Go source code
+// decl. Helper types are shared by all values that need them. An empty decl
+// reserves name.
+//
+// Some helper types have fixed names, like UUID, which may already be used by
+// a declared type or a different helper type. Such names are recorded in
+// options.collidingHelperNames so that they can be reserved for the helper
+// types when the types are generated again.
+func (options *generateOptions) declareHelper(name, decl string) {
+	if options.helperDecls == nil {
+		options.helperDecls = make(map[string]string)
+	}
+	_, isTypeDecl := options.typeDecls[name]
+	helperDecl := options.helperDecls[name]
+	if isTypeDecl || helperDecl != "" && decl != "" && helperDecl != decl {
+		if options.collidingHelperNames == nil {
+			options.collidingHelperNames = make(map[string]struct{})
+		}
+		options.collidingHelperNames[name] = struct{}{}
+	}
+	options.helperDecls[name] = decl
+}
+
+// reserveTypeName returns a new type name for the value at path and reserves it
+// so that the types declared while generating the value's type do not use it.
+// At the root, the name is the generated type's name.
+func (options *generateOptions) reserveTypeName(path valuePath) string {
+	if len(path) == 1 {
+		return path[0]
+	}
+	name := options.newTypeName(path)
+	options.declareHelper(name, "")
+	return name
+}
+
+// declareReservedType declares the type called name, which was returned by
+// reserveTypeName, with underlying type typeStr and Go source code methods,
+// like methods or constants, for the value at path, and returns the Go type of
+// the value. Types with methods are declared with the helper types and types
+// without methods are declared with the other named types. At the root, the
+// type is the generated type itself, so only methods are declared and typeStr
+// is returned.
+func (options *generateOptions) declareReservedType(path valuePath, name, typeStr, methods string) string {
+	switch {
+	case len(path) == 1:
+		if methods != "" {
+			options.declareMethods(name, methods)
+		}
+		return typeStr
+	case methods == "":
+		delete(options.helperDecls, name)
+		options.typeDecls[name] = typeStr
+		return name
+	default:
+		options.declareHelper(name, "type "+name+" "+typeStr+"\n\n"+methods)
+		return name
+	}
+}
+
+// declareMethods declares Go source code decl, like methods or constants, for
+// the type called name. decl is generated with the helper types, but is
+// declared under a different key so that helper types cannot collide with it.
+func (options *generateOptions) declareMethods(name, decl string) {
+	options.declareHelper(name+" methods", decl)
+}
+
+// scratch returns a copy of options for generating types that may not be used.
+// Declarations and imports added to the copy are only added to options if the
+// copy is committed.
+func (options *generateOptions) scratch() *generateOptions {
+	scratch := *options
+	scratch.collidingHelperNames = maps.Clone(options.collidingHelperNames)
+	scratch.enumTypeNames = maps.Clone(options.enumTypeNames)
+	scratch.helperDecls = maps.Clone(options.helperDecls)
+	scratch.imports = maps.Clone(options.imports)
+	scratch.originalValues = maps.Clone(options.originalValues)
+	scratch.recursiveTypes = slices.Clip(options.recursiveTypes)
+	scratch.typeDecls = maps.Clone(options.typeDecls)
+	scratch.typeNamesByTypeStr = maps.Clone(options.typeNamesByTypeStr)
+	return &scratch
+}
+
+// commit adds the declarations and imports of scratch, which was returned by
+// options.scratch, to options.
+func (options *generateOptions) commit(scratch *generateOptions) {
+	*options = *scratch
+}
@@ -0,0 +1,306 @@
+package jsonstruct
+
+import (
+	"bytes"
+	"fmt"
+	"go/token"
+	"maps"
+	"slices"
+	"strconv"
+)
+
+// maxDiscriminatorValues is the maximum number of distinct values of a
+// discriminator property.
+const maxDiscriminatorValues = 32
+
+// A variant describes the objects of a discriminated union that have the same
+// discriminator value.
+type variant struct {
+	objects          int
+	objectProperties map[string]*value // Property values, or nil if shared with the other variants.
+	propertyObjects  map[string]int    // Property name to number of objects with the property.
+}
+
+// observeVariants observes the properties of the object a for each of its
+// candidate discriminators, which are the configured discriminators or, if
+// union detection is enabled, its string properties. Candidates whose values
+// are not always strings or which have too many distinct values are recorded
+// as nil.
+//
+// The values of the properties of each variant are observed separately so that
+// variants can have different types for properties with the same name. To
+// avoid observing deeply nested objects once per candidate discriminator per
+// level of nesting, variants of objects within variants only record the names
+// of their properties and share their property values with each other.
+func (v *value) observeVariants(a map[string]any, options *observeOptions) {
+	variantObserveOptions := *options
+	variantObserveOptions.inVariant = true
+	for discriminator, discriminatorValue := range a {
+		if !options.detectUnions && !slices.Contains(options.discriminators, discriminator) {
+			continue
+		}
+		if v.variants == nil {
+			v.variants = make(map[string]map[string]*variant)
+		}
+		variants, ok := v.variants[discriminator]
+		if ok && variants == nil {
+			continue
+		}
+		s, ok := discriminatorValue.(string)
+		if !ok || s == "" {
+			v.variants[discriminator] = nil
+			continue
+		}
+		if variants == nil {
+			variants = make(map[string]*variant)
+			v.variants[discriminator] = variants
+		}
+		discriminatorVariant, ok := variants[s]
+		if !ok {
+			if len(variants) >= maxDiscriminatorValues {
+				v.variants[discriminator] = nil
+				continue
+			}
+			discriminatorVariant = &variant{
+				propertyObjects: make(map[string]int),
+			}
+			if !options.inVariant {
+				discriminatorVariant.objectProperties = make(map[string]*value)
+			}
+			variants[s] = discriminatorVariant
+		}
+		discriminatorVariant.objects++
+		for property, propertyValue := range a {
+			discriminatorVariant.propertyObjects[property]++
+			if discriminatorVariant.objectProperties != nil {
+				discriminatorVariant.objectProperties[property] = discriminatorVariant.objectProperties[property].observe(propertyValue, &variantObserveOptions)
+			}
+		}
+	}
+}
+
+// mergeVariants returns the merged variants of a and b.
+func mergeVariants(a, b map[string]map[string]*variant) map[string]map[string]*variant {
+	if a == nil && b == nil {
+		return nil
+	}
+	merged := make(map[string]map[string]*variant)
+	for _, variants := range []map[string]map[string]*variant{a, b} {
+		for discriminator, discriminatorVariants := range variants {
+			mergedVariants, ok := merged[discriminator]
+			switch {
+			case ok && mergedVariants == nil:
+				continue
+			case discriminatorVariants == nil:
+				merged[discriminator] = nil
+				continue
+			case mergedVariants == nil:
+				mergedVariants = make(map[string]*variant)
+				merged[discriminator] = mergedVariants
+			}
+			for discriminatorValue, discriminatorVariant := range discriminatorVariants {
+				mergedVariant, ok := mergedVariants[discriminatorValue]
+				if !ok {
+					mergedVariant = &variant{
+						objectProperties: make(map[string]*value),
+						propertyObjects:  make(map[string]int),
+					}
+					mergedVariants[discriminatorValue] = mergedVariant
+				}
+				mergedVariant.objects += discriminatorVariant.objects
+				for property, objects := range discriminatorVariant.propertyObjects {
+					mergedVariant.propertyObjects[property] += objects
+				}
+				switch {
+				case discriminatorVariant.objectProperties == nil:
+					mergedVariant.objectProperties = nil
+				case mergedVariant.objectProperties != nil:
+					for property, value := range discriminatorVariant.objectProperties {
+						mergedVariant.objectProperties[property] = mergedVariant.objectProperties[property].merge(value)
+					}
+				}
+			}
+		}
+	}
+	return merged
+}
+
+// discriminator returns the name of the discriminator property of the object
+// v, or the empty string if v is not a discriminated union.
+//
+// Candidate discriminators are properties that are always present with a
+// string value and have at least two distinct values. Configured
+// discriminators are preferred. Otherwise, if union detection is enabled, the
+// candidate with few distinct values that best partitions the other properties
+// is chosen.
+func (v *value) discriminator(options *generateOptions) string {
+	var candidates []string
+	for property, variants := range v.variants {
+		if len(variants) >= 2 && v.objectProperties[property].strings == v.objects {
+			candidates = append(candidates, property)
+		}
+	}
+	for _, discriminator := range options.discriminators {
+		if slices.Contains(candidates, discriminator) {
+			return discriminator
+		}
+	}
+	if !options.detectUnions {
+		return ""
+	}
+	bestCandidate, bestScore := "", 0
+	for _, candidate := range slices.Sorted(slices.Values(candidates)) {
+		if 2*len(v.variants[candidate]) > v.objects {
+			continue
+		}
+		score := v.partitionScore(candidate)
+		switch {
+		case score > bestScore:
+			bestCandidate, bestScore = candidate, score
+		case score == bestScore && score > 0 && len(v.variants[candidate]) < len(v.variants[bestCandidate]):
+			bestCandidate = candidate
+		}
+	}
+	return bestCandidate
+}
+
+// partitionScore returns the number of optional properties of the object v
+// that, within each variant of discriminator, are either always present or
+// never present.
+func (v *value) partitionScore(discriminator string) int {
+	score := 0
+FOR:
+	for property, propertyValue := range v.objectProperties {
+		if property == discriminator || propertyValue.observations == v.objects {
+			continue
+		}
+		for _, variant := range v.variants[discriminator] {
+			if objects, ok := variant.propertyObjects[property]; ok && objects != variant.objects {
+				continue FOR
+			}
+		}
+		score++
+	}
+	return score
+}
+
+// variantValue returns the object value of the variant of the discriminated
+// union v. If the variant does not have its own property values then its
+// properties are shared with v and the other variants.
+func (v *value) variantValue(variant *variant) *value {
+	if variant.objectProperties != nil {
+		return &value{
+			observations:     variant.objects,
+			objects:          variant.objects,
+			objectProperties: variant.objectProperties,
+		}
+	}
+	variantValue := &value{
+		observations:     variant.objects,
+		objects:          variant.objects,
+		objectProperties: make(map[string]*value, len(variant.propertyObjects)),
+		propertyObjects:  variant.propertyObjects,
+	}
+	for property := range variant.propertyObjects {
+		variantValue.objectProperties[property] = v.objectProperties[property]
+	}
+	return variantValue
+}
+
+// unionTypeStr returns the Go type of the discriminated union v, which is
+// located at path, and declares its interface, variant, and wrapper types.
+//
+// The wrapper type has a single field, Value, containing one of the variant
+// types, and implements encoding/json.Marshaler and encoding/json.Unmarshaler
+// by dispatching on the discriminator. At the root, the wrapper type is the
+// generated type itself.
+func (v *value) unionTypeStr(path valuePath, discriminator string, options *generateOptions) string {
+	name := options.reserveTypeName(path)
+	interfaceName := name + "Value"
+	for base, n := interfaceName, 2; !options.isTypeNameAvailable(path, interfaceName); n++ {
+		interfaceName = base + strconv.Itoa(n)
+	}
+	options.declareHelper(interfaceName, "")
+	wrapperTypeStr := fmt.Sprintf("struct {\nValue %s\n}", interfaceName)
+
+	variants := v.variants[discriminator]
+	discriminatorValues := slices.Sorted(maps.Keys(variants))
+	variantNames := make(map[string]string, len(discriminatorValues))
+	variantTypeStrs := make(map[string]string, len(discriminatorValues))
+	for _, discriminatorValue := range discriminatorValues {
+		variantName := name + options.exportNameFunc(discriminatorValue)
+		if !token.IsIdentifier(variantName) {
+			variantName = name + "Variant"
+		}
+		for base, n := variantName, 2; !options.isTypeNameAvailable(path, variantName); n++ {
+			variantName = base + strconv.Itoa(n)
+		}
+		variantNames[discriminatorValue] = variantName
+		options.declareHelper(variantName, "")
+	}
+	for _, discriminatorValue := range discriminatorValues {
+		variantTypeStrs[discriminatorValue] = v.variantValue(variants[discriminatorValue]).structTypeStr(path, options)
+	}
+
+	options.imports["encoding/json"] = struct{}{}
+	options.imports["fmt"] = struct{}{}
+	b := &bytes.Buffer{}
+	fmt.Fprintf(b, "// %s is one of ", interfaceName)
+	for i, discriminatorValue := range discriminatorValues {
+		switch {
+		case i == 0:
+		case i == len(discriminatorValues)-1 && i == 1:
+			fmt.Fprintf(b, " or ")
+		case i == len(discriminatorValues)-1:
+			fmt.Fprintf(b, ", or ")
+		default:
+			fmt.Fprintf(b, ", ")
+		}
+		fmt.Fprintf(b, "%s", variantNames[discriminatorValue])
+	}
+	fmt.Fprintf(b, ", depending on the value of %q.\n", discriminator)
+	fmt.Fprintf(b, "type %s interface {\n", interfaceName)
+	fmt.Fprintf(b, "is%s()\n", interfaceName)
+	fmt.Fprintf(b, "}")
+	options.declareHelper(interfaceName, b.String())
+
+	for _, discriminatorValue := range discriminatorValues {
+		variantName := variantNames[discriminatorValue]
+		options.declareHelper(variantName, fmt.Sprintf(""+
+			"type %s %s\n"+
+			"\n"+
+			"func (%s) is%s() {}",
+			variantName, variantTypeStrs[discriminatorValue],
+			variantName, interfaceName,
+		))
+	}
+
+	b.Reset()
+	fmt.Fprintf(b, "// UnmarshalJSON implements encoding/json.Unmarshaler.\n")
+	fmt.Fprintf(b, "func (v *%s) UnmarshalJSON(data []byte) error {\n", name)
+	fmt.Fprintf(b, "var discriminator struct {\n")
+	fmt.Fprintf(b, "Value string `json:%q`\n", discriminator)
+	fmt.Fprintf(b, "}\n")
+	fmt.Fprintf(b, "if err := json.Unmarshal(data, &discriminator); err != nil {\n")
+	fmt.Fprintf(b, "return err\n")
+	fmt.Fprintf(b, "}\n")
+	fmt.Fprintf(b, "switch discriminator.Value {\n")
+	for _, discriminatorValue := range discriminatorValues {
+		fmt.Fprintf(b, "case %q:\n", discriminatorValue)
+		fmt.Fprintf(b, "var value %s\n", variantNames[discriminatorValue])
+		fmt.Fprintf(b, "if err := json.Unmarshal(data, &value); err != nil {\n")
+		fmt.Fprintf(b, "return err\n")
+		fmt.Fprintf(b, "}\n")
+		fmt.Fprintf(b, "v.Value = value\n")
+	}
+	fmt.Fprintf(b, "default:\n")
+	fmt.Fprintf(b, "return fmt.Errorf(\"%%q: unknown %s\", discriminator.Value)\n", discriminator)
+	fmt.Fprintf(b, "}\n")
+	fmt.Fprintf(b, "return nil\n")
+	fmt.Fprintf(b, "}\n")
+	fmt.Fprintf(b, "\n// MarshalJSON implements encoding/json.Marshaler.\n")
+	fmt.Fprintf(b, "func (v %s) MarshalJSON() ([]byte, error) {\n", name)
+	fmt.Fprintf(b, "return json.Marshal(v.Value)\n")
+	fmt.Fprintf(b, "}")
+	return options.declareReservedType(path, name, wrapperTypeStr, b.String())
+}
@@ -0,0 +1,127 @@
+package jsonstruct
+
+import (
+	"bytes"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// unparsablePropertiesMethodNames are the names of the methods generated for
+// structs with unparsable properties.
+var unparsablePropertiesMethodNames = []string{"MarshalJSON", "UnmarshalJSON"}
+
+// isUnparsableProperty returns true if key cannot be used as a struct tag name
+// by encoding/json.
+func isUnparsableProperty(key string) bool {
+	if key == "" {
+		return true
+	}
+	for _, r := range key {
+		switch {
+		case strings.ContainsRune("!#$%&()*+-./:;<=>?@[]^_{|}~", r):
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+		default:
+			return true
+		}
+	}
+	return false
+}
+
+// isSkippedProperty returns true if key is skipped by
+// UnparsablePropertiesComment, which is if it is empty or contains a space, a
+// comma, a quote, or a backtick. Other keys that encoding/json cannot use as
+// struct tag names, like "€", are generated as fields, which encoding/json
+// matches by field name instead.
+func isSkippedProperty(key string) bool {
+	return key == "" || strings.ContainsAny(key, " \",`")
+}
+
+// skippedStructTags returns struct tags for structTagNames that skip the
+// field.
+func skippedStructTags(structTagNames []string) string {
+	structTags := make([]string, 0, len(structTagNames))
+	for _, structTagName := range structTagNames {
+		structTags = append(structTags, structTagName+`:"-"`)
+	}
+	return strings.Join(structTags, " ")
+}
+
+// unparsablePropertiesTypeStr returns the Go type of the object v, which is
+// located at path, if it has properties that cannot be used as encoding/json
+// struct tag names and options.unparsableProperties is
+// UnparsablePropertiesMethods, or the empty string otherwise. The generated
+// struct implements encoding/json.Marshaler and encoding/json.Unmarshaler to
+// marshal these properties. At the root, the struct is the generated type
+// itself.
+func (v *value) unparsablePropertiesTypeStr(path valuePath, options *generateOptions) string {
+	if options.unparsableProperties != UnparsablePropertiesMethods {
+		return ""
+	}
+	var properties, unparsableProperties []string
+	for property := range v.objectProperties {
+		properties = append(properties, property)
+		if isUnparsableProperty(property) {
+			unparsableProperties = append(unparsableProperties, property)
+		}
+	}
+	if len(unparsableProperties) == 0 {
+		return ""
+	}
+	slices.Sort(unparsableProperties)
+	fieldNames := options.fieldNames(path, properties, unparsablePropertiesMethodNames)
+
+	name := options.reserveTypeName(path)
+	typeStr, omitZeroProperties := v.structTypeStrWithUnparsableProperties(path, UnparsablePropertiesMethods, options)
+
+	options.imports["encoding/json"] = struct{}{}
+	b := &bytes.Buffer{}
+	fmt.Fprintf(b, "// UnmarshalJSON implements encoding/json.Unmarshaler.\n")
+	fmt.Fprintf(b, "func (t *%s) UnmarshalJSON(data []byte) error {\n", name)
+	fmt.Fprintf(b, "type plain %s\n", name)
+	fmt.Fprintf(b, "if err := json.Unmarshal(data, (*plain)(t)); err != nil {\n")
+	fmt.Fprintf(b, "return err\n")
+	fmt.Fprintf(b, "}\n")
+	fmt.Fprintf(b, "var properties map[string]json.RawMessage\n")
+	fmt.Fprintf(b, "if err := json.Unmarshal(data, &properties); err != nil {\n")
+	fmt.Fprintf(b, "return err\n")
+	fmt.Fprintf(b, "}\n")
+	for _, property := range unparsableProperties {
+		fmt.Fprintf(b, "if value, ok := properties[%q]; ok {\n", property)
+		fmt.Fprintf(b, "if err := json.Unmarshal(value, &t.%s); err != nil {\n", fieldNames[property].name)
+		fmt.Fprintf(b, "return err\n")
+		fmt.Fprintf(b, "}\n")
+		fmt.Fprintf(b, "}\n")
+	}
+	fmt.Fprintf(b, "return nil\n")
+	fmt.Fprintf(b, "}\n")
+	fmt.Fprintf(b, "\n// MarshalJSON implements encoding/json.Marshaler.\n")
+	fmt.Fprintf(b, "func (t %s) MarshalJSON() ([]byte, error) {\n", name)
+	fmt.Fprintf(b, "type plain %s\n", name)
+	fmt.Fprintf(b, "data, err := json.Marshal(plain(t))\n")
+	fmt.Fprintf(b, "if err != nil {\n")
+	fmt.Fprintf(b, "return nil, err\n")
+	fmt.Fprintf(b, "}\n")
+	fmt.Fprintf(b, "var properties map[string]json.RawMessage\n")
+	fmt.Fprintf(b, "if err := json.Unmarshal(data, &properties); err != nil {\n")
+	fmt.Fprintf(b, "return nil, err\n")
+	fmt.Fprintf(b, "}\n")
+	for _, property := range unparsableProperties {
+		// Properties that may be absent are omitted if they are zero, like
+		// fields with ,omitempty or ,omitzero tags.
+		if omitZeroProperties[property] {
+			options.imports["reflect"] = struct{}{}
+			fmt.Fprintf(b, "if !reflect.ValueOf(t.%s).IsZero() {\n", fieldNames[property].name)
+		}
+		fmt.Fprintf(b, "if properties[%q], err = json.Marshal(t.%s); err != nil {\n", property, fieldNames[property].name)
+		fmt.Fprintf(b, "return nil, err\n")
+		fmt.Fprintf(b, "}\n")
+		if omitZeroProperties[property] {
+			fmt.Fprintf(b, "}\n")
+		}
+	}
+	fmt.Fprintf(b, "return json.Marshal(properties)\n")
+	fmt.Fprintf(b, "}")
+	return options.declareReservedType(path, name, typeStr, b.String())
+}
@@ -0,0 +1,49 @@
+package jsonstruct
+
+import (
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/alecthomas/assert/v2"
+)
+
+func TestIsUnparsableProperty(t *testing.T) {
+	expected := map[string]bool{
+		"":                       true,
+		"-":                      false,
+		"Content-Type":           false,
+		"Content-Type, Encoding": true,
+		"a`b":                    true,
+		"display name":           true,
+		"id":                     false,
+		"it's":                   true,
+		"q\"uote":                true,
+		"x.y/z@example.com":      false,
+		"名前":                     false,
+	}
+	for _, property := range slices.Sorted(maps.Keys(expected)) {
+		t.Run(property, func(t *testing.T) {
+			assert.Equal(t, expected[property], isUnparsableProperty(property))
+		})
+	}
+}
+
+func TestIsSkippedProperty(t *testing.T) {
+	expected := map[string]bool{
+		"":                       true,
+		"-":                      false,
+		"Content-Type, Encoding": true,
+		"a`b":                    true,
+		"display name":           true,
+		"id":                     false,
+		"it's":                   false,
+		"q\"uote":                true,
+		"€":                      false,
+	}
+	for _, property := range slices.Sorted(maps.Keys(expected)) {
+		t.Run(property, func(t *testing.T) {
+			assert.Equal(t, expected[property], isSkippedProperty(property))
+		})
+	}
+}
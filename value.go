@@ -5,7 +5,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"maps"
+	"math"
 	"slices"
+	"strconv"
 	"strings"
 	"time"
 
@@ -14,46 +16,131 @@ import (
 
 // An value describes an observed value.
 type value struct {
-	observations        int
-	empties             int
-	zeros               int
-	arrays              int
-	bools               int
-	boolStrings         int
-	float64s            int
-	float64Strings      int
-	ints                int
-	intStrings          int
-	nulls               int
-	objects             int
-	strings             int
-	times               int // time.Time is an implicit more specific type than string.
-	arrayElements       *value
-	allObjectProperties *value
-	objectProperties    map[string]*value
+	observations         int
+	empties              int
+	zeros                int
+	arrays               int
+	bools                int
+	boolStrings          int
+	float64s             int
+	integralFloat64s     int // Floats that are integers that fit in an int64.
+	float64Strings       int
+	ints                 int
+	intStrings           int
+	nulls                int
+	objects              int
+	strings              int
+	times                int // time.Time is an implicit more specific type than string.
+	arrayElements        *value
+	arrayLength          int
+	variableArrayLengths bool
+	tupleElements        []*value // Values at each position of fixed-length arrays.
+	allObjectProperties  *value
+	objectProperties     map[string]*value
+	variants             map[string]map[string]*variant // Discriminator property name to discriminator value to variant.
+	propertyObjects      map[string]int                 // Number of objects with each property, if v is a variant with shared properties.
+	stringValues         map[string]int
+	tooManyStringValues  bool
+	intValues            map[int64]int
+	tooManyIntValues     bool
+	timeLayouts          map[string]int // Time layout to number of matching strings.
+	minInt               int64
+	minFractionDigits    int // Minimum number of fractional digits of non-integer numbers.
+	maxFractionDigits    int // Maximum number of fractional digits of non-integer numbers.
+	maxInt               int64
+	largeUints           int // Integers greater than math.MaxInt64.
+	bigInts              int // Integers that do not fit in a uint64 or an int64.
+	unsafeInts           int // Integers that cannot be represented exactly by a float64.
+	negativeIntStrings   int // Negative integer strings.
+	largeUintStrings     int // Integer strings greater than math.MaxInt64 that fit in a uint64.
+	bigIntStrings        int // Integer strings that do not fit in a uint64 or an int64.
+	durations            int
+	iso8601Durations     int
+	stringFormats        map[string]int // String format name to number of matching strings.
+	base64Encodings      map[string]int // Base64 encoding name to number of valid strings.
+}
+
+type observeOptions struct {
+	detectBase64           bool
+	detectBase64Variants   bool
+	detectDurations        bool
+	detectISO8601Durations bool
+	detectUnions           bool
+	discriminators         []string
+	inVariant              bool
+	largeInts              bool
+	maxIntEnumValues       int
+	maxStringEnumValues    int
+	stringFormats          []StringFormat
+	timeLayouts            []string
+	trackArrayLengths      bool
+	trackFractionDigits    bool
+	trackIntRange          bool
+	trackIntegralFloats    bool
 }
 
 type generateOptions struct {
+	collidingHelperNames     map[string]struct{}
+	decimalImport            string
+	decimalNames             []string
+	decimalType              string
+	deduplicateTypes         DeduplicateTypesType
+	detectDecimals           bool
+	detectEpochTimes         bool
+	detectMaps               bool
+	detectRecursiveTypes     bool
+	detectTuples             bool
+	detectUnions             bool
+	discriminators           []string
+	enumTypeNames            map[string]string
+	enumValidation           bool
+	epochTimeSuffixes        []string
 	exportNameFunc           ExportNameFunc
+	extractNestedTypes       bool
+	helperDecls              map[string]string
 	imports                  map[string]struct{}
+	intEnumNames             map[string]map[int64]string
 	intType                  string
+	intTypes                 IntTypesType
+	largeInts                LargeIntsType
+	mapOverrides             map[string]bool
+	mapThreshold             int
+	mergedValues             map[*value]*value
+	nullableTypes            NullableTypesType
+	numbers                  NumbersType
+	numbersOverrides         map[string]NumbersType
 	omitEmptyTags            OmitEmptyTagsType
 	omitZeroTags             OmitZeroTagsType
+	optionalTypes            bool
+	originalValues           map[*value]*value
+	pointers                 PointersType
+	polymorphicTypes         bool
+	recursiveGroups          map[*value]*recursiveGroup
+	recursiveTypes           []recursiveType
+	reservedTypeNames        map[string]struct{}
 	skipUnparsableProperties bool
+	stringFormats            []StringFormat
 	stringTags               bool
 	structTagNames           []string
+	timeLayouts              []string
+	typeDecls                map[string]string
+	typeNamesByTypeStr       map[string]string
+	typedMapKeys             bool
+	unparsableProperties     UnparsablePropertiesType
 	useJSONNumber            bool
 }
 
 type goType struct {
-	typeStr   string
-	omitEmpty bool
-	omitZero  bool
-	stringTag bool
+	typeStr          string
+	omitEmpty        bool
+	omitZero         bool
+	omitZeroRequired bool
+	stringTag        bool
+	comment          string
 }
 
 // observe merges a into v.
-func (v *value) observe(a any) *value {
+func (v *value) observe(a any, options *observeOptions) *value {
 	if v == nil {
 		v = &value{}
 	}
@@ -68,8 +155,9 @@ func (v *value) observe(a any) *value {
 			v.arrayElements = &value{}
 		}
 		for _, e := range a {
-			v.arrayElements = v.arrayElements.observe(e)
+			v.arrayElements = v.arrayElements.observe(e, options)
 		}
+		v.observeArrayLength(a, options)
 	case bool:
 		v.bools++
 		if !a {
@@ -82,12 +170,21 @@ func (v *value) observe(a any) *value {
 			v.empties++
 			v.zeros++
 		}
+		v.observeFractionDigits(strconv.FormatFloat(a, 'f', -1, 64), options)
+		v.observeIntegralFloat(a, options)
 	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
 		v.ints++
 		if a == 0 {
 			v.empties++
 			v.zeros++
 		}
+		if i, ok := toInt64(a); ok {
+			v.observeIntValue(i, options)
+			v.observeIntRange(i, options)
+			v.observeUnsafeInt(i, options)
+		} else {
+			v.observeLargeUint(options)
+		}
 	case nil:
 		v.nulls++
 		v.zeros++
@@ -100,8 +197,11 @@ func (v *value) observe(a any) *value {
 			v.objectProperties = make(map[string]*value)
 		}
 		for property, value := range a {
-			v.allObjectProperties = v.allObjectProperties.observe(value)
-			v.objectProperties[property] = v.objectProperties[property].observe(value)
+			v.allObjectProperties = v.allObjectProperties.observe(value, options)
+			v.objectProperties[property] = v.objectProperties[property].observe(value, options)
+		}
+		if options.detectUnions || len(options.discriminators) > 0 {
+			v.observeVariants(a, options)
 		}
 	case string:
 		if a == "" {
@@ -113,8 +213,10 @@ func (v *value) observe(a any) *value {
 		} else if err := json.Unmarshal([]byte(a), new(int)); err == nil {
 			v.float64Strings++
 			v.intStrings++
+			v.observeIntString(a, options)
 		} else if err := json.Unmarshal([]byte(a), new(float64)); err == nil {
 			v.float64Strings++
+			v.observeIntString(a, options)
 		}
 		if v.times == v.strings {
 			if t, err := time.Parse(time.RFC3339Nano, a); err == nil {
@@ -124,6 +226,11 @@ func (v *value) observe(a any) *value {
 				}
 			}
 		}
+		v.observeStringValue(a, options)
+		v.observeTimeLayouts(a, options)
+		v.observeDuration(a, options)
+		v.observeStringFormats(a, options)
+		v.observeBase64(a, options)
 		v.strings++
 	case json.Number:
 		if i, err := a.Int64(); err == nil {
@@ -131,11 +238,18 @@ func (v *value) observe(a any) *value {
 			if i == 0 {
 				v.zeros++
 			}
-		} else {
+			v.observeIntValue(i, options)
+			v.observeIntRange(i, options)
+			v.observeUnsafeInt(i, options)
+		} else if !v.observeLargeJSONNumber(a, options) {
 			v.float64s++
-			if f, err := a.Float64(); err == nil && f == 0 {
-				v.zeros++
+			if f, err := a.Float64(); err == nil {
+				if f == 0 {
+					v.zeros++
+				}
+				v.observeIntegralFloat(f, options)
 			}
+			v.observeFractionDigits(a.String(), options)
 		}
 	default:
 		panic(fmt.Errorf("%T: unhandled type", a))
@@ -143,8 +257,131 @@ func (v *value) observe(a any) *value {
 	return v
 }
 
-// goType returns the Go type of v.
-func (v *value) goType(observations int, options *generateOptions) goType {
+// merge returns a new value that combines the observations of v and other.
+func (v *value) merge(other *value) *value {
+	switch {
+	case v == nil && other == nil:
+		return nil
+	case v == nil:
+		v = &value{}
+	case other == nil:
+		other = &value{}
+	}
+	merged := &value{
+		observations:        v.observations + other.observations,
+		empties:             v.empties + other.empties,
+		zeros:               v.zeros + other.zeros,
+		arrays:              v.arrays + other.arrays,
+		bools:               v.bools + other.bools,
+		boolStrings:         v.boolStrings + other.boolStrings,
+		float64s:            v.float64s + other.float64s,
+		integralFloat64s:    v.integralFloat64s + other.integralFloat64s,
+		float64Strings:      v.float64Strings + other.float64Strings,
+		ints:                v.ints + other.ints,
+		intStrings:          v.intStrings + other.intStrings,
+		nulls:               v.nulls + other.nulls,
+		objects:             v.objects + other.objects,
+		strings:             v.strings + other.strings,
+		times:               v.times + other.times,
+		largeUints:          v.largeUints + other.largeUints,
+		bigInts:             v.bigInts + other.bigInts,
+		unsafeInts:          v.unsafeInts + other.unsafeInts,
+		negativeIntStrings:  v.negativeIntStrings + other.negativeIntStrings,
+		largeUintStrings:    v.largeUintStrings + other.largeUintStrings,
+		bigIntStrings:       v.bigIntStrings + other.bigIntStrings,
+		durations:           v.durations + other.durations,
+		iso8601Durations:    v.iso8601Durations + other.iso8601Durations,
+		arrayElements:       v.arrayElements.merge(other.arrayElements),
+		allObjectProperties: v.allObjectProperties.merge(other.allObjectProperties),
+		variants:            mergeVariants(v.variants, other.variants),
+		tooManyStringValues: v.tooManyStringValues || other.tooManyStringValues,
+	}
+	if !merged.tooManyStringValues {
+		merged.stringValues = mergeValueCounts(v.stringValues, other.stringValues)
+	}
+	merged.timeLayouts = mergeValueCounts(v.timeLayouts, other.timeLayouts)
+	v.mergeArrayLengths(other, merged)
+	switch {
+	case v.minFractionDigits != 0 && other.minFractionDigits != 0:
+		merged.minFractionDigits = min(v.minFractionDigits, other.minFractionDigits)
+	default:
+		merged.minFractionDigits = max(v.minFractionDigits, other.minFractionDigits)
+	}
+	merged.maxFractionDigits = max(v.maxFractionDigits, other.maxFractionDigits)
+	merged.stringFormats = mergeValueCounts(v.stringFormats, other.stringFormats)
+	merged.base64Encodings = mergeValueCounts(v.base64Encodings, other.base64Encodings)
+	switch {
+	case v.intRangeObservations() > 0 && other.intRangeObservations() > 0:
+		merged.minInt = min(v.minInt, other.minInt)
+		merged.maxInt = max(v.maxInt, other.maxInt)
+	case v.intRangeObservations() > 0:
+		merged.minInt, merged.maxInt = v.minInt, v.maxInt
+	case other.intRangeObservations() > 0:
+		merged.minInt, merged.maxInt = other.minInt, other.maxInt
+	}
+	merged.tooManyIntValues = v.tooManyIntValues || other.tooManyIntValues
+	if !merged.tooManyIntValues {
+		merged.intValues = mergeValueCounts(v.intValues, other.intValues)
+	}
+	if v.objectProperties != nil || other.objectProperties != nil {
+		merged.objectProperties = make(map[string]*value)
+		for property, value := range v.objectProperties {
+			merged.objectProperties[property] = value.merge(other.objectProperties[property])
+		}
+		for property, value := range other.objectProperties {
+			if _, ok := v.objectProperties[property]; !ok {
+				merged.objectProperties[property] = value.merge(nil)
+			}
+		}
+	}
+	return merged
+}
+
+// kinds returns whether arrays, booleans, numbers, objects, and strings were
+// observed in v.
+func (v *value) kinds() [5]bool {
+	return [...]bool{v.arrays > 0, v.bools > 0, v.float64s+v.ints > 0, v.objects > 0, v.strings > 0}
+}
+
+// isCompatible returns true if v and other could be merged without losing type
+// information. Objects are compatible if at least half of their combined
+// properties are common to both and all common properties are compatible.
+func (v *value) isCompatible(other *value) bool {
+	if v == nil || other == nil {
+		return true
+	}
+	vKinds, otherKinds := v.kinds(), other.kinds()
+	switch {
+	case vKinds == [5]bool{} || otherKinds == [5]bool{}:
+		return true
+	case vKinds != otherKinds:
+		return false
+	case !v.arrayElements.isCompatible(other.arrayElements):
+		return false
+	}
+	commonProperties := 0
+	for property, value := range v.objectProperties {
+		if otherValue, ok := other.objectProperties[property]; ok {
+			if !value.isCompatible(otherValue) {
+				return false
+			}
+			commonProperties++
+		}
+	}
+	allProperties := len(v.objectProperties) + len(other.objectProperties) - commonProperties
+	return 2*commonProperties >= allProperties
+}
+
+// goType returns the Go type of v, which is located at path.
+func (v *value) goType(path valuePath, observations int, options *generateOptions) goType {
+	v = v.normalizeNumbers(path, options)
+	if goType, ok := v.optionalGoType(path, observations, options); ok {
+		return goType
+	}
+	if goType, ok := v.pointersGoType(path, observations, options); ok {
+		return goType
+	}
+
 	// Determine the number of distinct types observed.
 	distinctTypes := 0
 	if v.arrays > 0 {
@@ -174,10 +411,23 @@ func (v *value) goType(observations int, options *generateOptions) goType {
 	case distinctTypes == 1 && v.arrays > 0:
 		fallthrough
 	case distinctTypes == 2 && v.arrays > 0 && v.nulls > 0:
-		elementGoType := v.arrayElements.goType(0, options)
+		if typeStr := v.fixedLengthTypeStr(path, options); typeStr != "" {
+			// The root type may have methods, so it is never a pointer.
+			if len(path) > 1 && (v.arrays < observations || v.nulls > 0) {
+				return goType{
+					typeStr:   "*" + typeStr,
+					omitEmpty: v.arrays+v.nulls < observations,
+				}
+			}
+			return goType{
+				typeStr: typeStr,
+			}
+		}
+		elementGoType := v.arrayElements.goType(path.appendElements(), 0, options)
 		return goType{
 			typeStr:   "[]" + elementGoType.typeStr,
 			omitEmpty: v.arrays+v.nulls < observations && v.empties == 0,
+			comment:   elementGoType.comment,
 		}
 	case distinctTypes == 1 && v.bools > 0:
 		return goType{
@@ -187,31 +437,71 @@ func (v *value) goType(observations int, options *generateOptions) goType {
 		}
 	case distinctTypes == 2 && v.bools > 0 && v.nulls > 0:
 		return goType{
-			typeStr: "*bool",
+			typeStr: options.nullableTypeStr("bool"),
 		}
 	case distinctTypes == 1 && v.float64s > 0:
+		typeStr := "float64"
+		if v.isDecimal(path, options) {
+			typeStr = options.decimalTypeStr()
+		}
 		return goType{
-			typeStr:   "float64",
+			typeStr:   typeStr,
 			omitEmpty: v.float64s < observations && v.empties == 0,
 			omitZero:  v.zeros == 0,
 		}
 	case distinctTypes == 2 && v.float64s > 0 && v.nulls > 0:
+		if v.isDecimal(path, options) {
+			return goType{
+				typeStr: options.nullableTypeStr(options.decimalTypeStr()),
+			}
+		}
 		return goType{
-			typeStr: "*float64",
+			typeStr: options.nullableTypeStr("float64"),
 		}
 	case distinctTypes == 1 && v.ints > 0:
+		var typeStr string
+		if v.integralFloat64s > 0 {
+			// Integral floats are only observed as integers if numbers are
+			// normalized.
+			typeStr = v.integralFloatIntTypeStr(path, options)
+		} else if epochTimeTypeStr := v.epochTimeTypeStr(path, options); epochTimeTypeStr != "" {
+			typeStr = epochTimeTypeStr
+		} else if enumTypeStr := v.intEnumTypeStr(path, options); enumTypeStr != "" {
+			typeStr = enumTypeStr
+		} else {
+			typeStr = v.intTypeStr(path, options)
+		}
 		return goType{
-			typeStr:   options.intType,
+			typeStr:   typeStr,
 			omitEmpty: v.ints < observations && v.empties == 0,
 			omitZero:  v.zeros == 0,
 		}
 	case distinctTypes == 2 && v.ints > 0 && v.nulls > 0:
+		var typeStr string
+		if v.integralFloat64s > 0 {
+			// Integral floats are only observed as integers if numbers are
+			// normalized.
+			typeStr = v.integralFloatIntTypeStr(path, options)
+		} else if epochTimeTypeStr := v.epochTimeTypeStr(path, options); epochTimeTypeStr != "" {
+			typeStr = epochTimeTypeStr
+		} else if enumTypeStr := v.intEnumTypeStr(path, options); enumTypeStr != "" {
+			typeStr = enumTypeStr
+		} else {
+			typeStr = v.intTypeStr(path, options)
+		}
 		return goType{
-			typeStr: "*" + options.intType,
+			typeStr: options.nullableTypeStr(typeStr),
 		}
 	case distinctTypes == 2 && v.float64s > 0 && v.ints > 0:
 		omitEmpty := v.float64s+v.ints < observations && v.empties == 0
-		if options.useJSONNumber {
+		if v.isDecimal(path, options) {
+			return goType{
+				typeStr:   options.decimalTypeStr(),
+				omitEmpty: omitEmpty,
+				omitZero:  v.zeros == 0,
+			}
+		}
+		if options.useJSONNumber || v.hasUnsafeInts(options) {
 			options.imports["encoding/json"] = struct{}{}
 			return goType{
 				typeStr:   "json.Number",
@@ -225,15 +515,21 @@ func (v *value) goType(observations int, options *generateOptions) goType {
 			omitZero:  v.zeros == 0,
 		}
 	case distinctTypes == 3 && v.float64s > 0 && v.ints > 0 && v.nulls > 0:
-		if options.useJSONNumber {
+		if v.isDecimal(path, options) {
+			return goType{
+				typeStr:  options.nullableTypeStr(options.decimalTypeStr()),
+				omitZero: v.zeros == 0,
+			}
+		}
+		if options.useJSONNumber || v.hasUnsafeInts(options) {
 			options.imports["encoding/json"] = struct{}{}
 			return goType{
-				typeStr:  "*json.Number",
+				typeStr:  options.nullableTypeStr("json.Number"),
 				omitZero: v.zeros == 0,
 			}
 		}
 		return goType{
-			typeStr:  "*float64",
+			typeStr:  options.nullableTypeStr("float64"),
 			omitZero: v.zeros == 0,
 		}
 	case distinctTypes == 1 && v.objects > 0:
@@ -260,92 +556,59 @@ func (v *value) goType(observations int, options *generateOptions) goType {
 				}
 			}
 		}
-		hasUnparsableProperties := false
-		for k := range v.objectProperties {
-			if strings.ContainsRune(k, ' ') {
-				hasUnparsableProperties = true
-				break
-			}
-		}
-		if hasUnparsableProperties && !options.skipUnparsableProperties {
-			valueGoType := v.allObjectProperties.goType(0, options)
+		if v.isMap(path, options) {
+			valueGoType := v.allObjectProperties.goType(path.appendElements(), 0, options)
 			return goType{
-				typeStr:   "map[string]" + valueGoType.typeStr,
+				typeStr:   "map[" + v.mapKeyType(options) + "]" + valueGoType.typeStr,
 				omitEmpty: v.objects+v.nulls < observations,
 			}
 		}
-		b := &bytes.Buffer{}
-		fmt.Fprintf(b, "struct {\n")
-		var unparsableProperties []string
-		for _, property := range slices.Sorted(maps.Keys(v.objectProperties)) {
-			if isUnparsableProperty(property) {
-				unparsableProperties = append(unparsableProperties, property)
-				continue
-			}
-			goType := v.objectProperties[property].goType(v.objects, options)
-			var omitEmpty bool
-			switch options.omitEmptyTags {
-			case OmitEmptyTagsNever:
-				omitEmpty = false
-			case OmitEmptyTagsAlways:
-				omitEmpty = true
-			case OmitEmptyTagsAuto:
-				omitEmpty = goType.omitEmpty
-			}
-			var omitZero bool
-			switch options.omitZeroTags {
-			case OmitZeroTagsNever:
-				omitZero = false
-			case OmitZeroTagsAlways:
-				omitZero = true
-			case OmitZeroTagsAuto:
-				omitZero = goType.omitZero
-			}
-
-			tags, _ := structtag.Parse("")
-			var structTagOptions []string
-			if omitEmpty {
-				structTagOptions = append(structTagOptions, "omitempty")
-			}
-			if omitZero {
-				structTagOptions = append(structTagOptions, "omitzero")
+		var typeStr string
+		discriminator := v.discriminator(options)
+		if recursiveTypeRef, ok := options.recursiveTypeRef(path); ok && discriminator == "" {
+			return goType{
+				typeStr:   recursiveTypeRef,
+				omitEmpty: v.objects < observations,
 			}
-			if goType.stringTag {
-				structTagOptions = append(structTagOptions, "string")
+		}
+		if discriminator != "" {
+			typeStr = v.unionTypeStr(path, discriminator, options)
+		} else if recursiveTypeStr := v.recursiveTypeStr(path, options); recursiveTypeStr != "" {
+			typeStr = recursiveTypeStr
+		} else {
+			// If v was merged with compatible objects then generate the
+			// struct from the merged value so that all share the same type.
+			structValue := v
+			if mergedValue, ok := options.mergedValues[options.originalValue(v)]; ok {
+				structValue = mergedValue
 			}
-			for _, structTagName := range options.structTagNames {
-				tag := &structtag.Tag{
-					Key:     structTagName,
-					Name:    property,
-					Options: structTagOptions,
+			if unparsablePropertiesTypeStr := structValue.unparsablePropertiesTypeStr(path, options); unparsablePropertiesTypeStr != "" {
+				typeStr = unparsablePropertiesTypeStr
+			} else {
+				typeStr = structValue.structTypeStr(path, options)
+				if options.extractNestedTypes && len(path) > 1 {
+					typeStr = options.declareType(path, typeStr)
 				}
-				_ = tags.Set(tag)
 			}
-
-			fmt.Fprintf(b, "%s %s `%s`\n", options.exportNameFunc(property), goType.typeStr, tags)
 		}
-		for _, property := range unparsableProperties {
-			fmt.Fprintf(b, "// %q cannot be unmarshalled into a struct field by encoding/json.\n", property)
-		}
-		fmt.Fprintf(b, "}")
 		switch {
 		case observations == 0:
 			return goType{
-				typeStr: b.String(),
+				typeStr: typeStr,
 			}
 		case v.objects == observations:
 			return goType{
-				typeStr: b.String(),
+				typeStr: typeStr,
 			}
 		case v.objects < observations && v.nulls == 0:
 			return goType{
-				typeStr:   "*" + b.String(),
+				typeStr:   "*" + typeStr,
 				omitEmpty: true,
 				omitZero:  v.zeros == 0,
 			}
 		default:
 			return goType{
-				typeStr:   "*" + b.String(),
+				typeStr:   "*" + typeStr,
 				omitEmpty: v.objects+v.nulls < observations,
 				omitZero:  v.zeros == 0,
 			}
@@ -373,7 +636,14 @@ func (v *value) goType(observations int, options *generateOptions) goType {
 				omitEmpty: v.intStrings < v.strings,
 				omitZero:  v.zeros == 0,
 			}
-		case options.stringTags && v.strings == v.float64Strings:
+		case options.stringTags && v.strings == v.intStrings+v.largeUintStrings && v.largeUintStrings > 0 && v.negativeIntStrings == 0:
+			return goType{
+				typeStr:   "uint64",
+				stringTag: true,
+				omitEmpty: v.intStrings+v.largeUintStrings < v.strings,
+				omitZero:  v.zeros == 0,
+			}
+		case options.stringTags && v.strings == v.float64Strings && v.largeUintStrings+v.bigIntStrings == 0:
 			return goType{
 				typeStr:   "float64",
 				stringTag: true,
@@ -381,25 +651,220 @@ func (v *value) goType(observations int, options *generateOptions) goType {
 				omitZero:  v.zeros == 0,
 			}
 		default:
+			typeStr, comment := v.stringTypeStr(path, options)
 			return goType{
-				typeStr:   "string",
+				typeStr:   typeStr,
 				omitEmpty: v.strings < observations && v.empties == 0,
 				omitZero:  v.zeros == 0,
+				comment:   comment,
 			}
 		}
 	case distinctTypes == 2 && v.strings > 0 && v.nulls > 0 && v.times == v.strings:
-		options.imports["time"] = struct{}{}
+		if !options.hasSQLNullType("time.Time") {
+			options.imports["time"] = struct{}{}
+		}
 		return goType{
-			typeStr: "*time.Time",
+			typeStr: options.nullableTypeStr("time.Time"),
 		}
 	case distinctTypes == 2 && v.strings > 0 && v.nulls > 0:
+		typeStr, comment := v.stringTypeStr(path, options)
+		if typeStr == "[]byte" {
+			// encoding/json unmarshals null into a nil []byte.
+			return goType{
+				typeStr: typeStr,
+			}
+		}
 		return goType{
-			typeStr: "*string",
+			typeStr: options.nullableTypeStr(typeStr),
+			comment: comment,
 		}
 	default:
+		if options.polymorphicTypes {
+			if goType, ok := v.polymorphicGoType(path, observations, options); ok {
+				return goType
+			}
+		}
 		return goType{
 			typeStr:   "any",
 			omitEmpty: v.arrays+v.bools+v.float64s+v.ints+v.nulls+v.objects+v.strings < observations,
 		}
 	}
 }
+
+// pointerTypeStr returns a nullable type for typeStr. Pointer types are already
+// nullable.
+func pointerTypeStr(typeStr string) string {
+	if strings.HasPrefix(typeStr, "*") {
+		return typeStr
+	}
+	return "*" + typeStr
+}
+
+// stringTypeStr returns the Go type of the string v, which is located at path,
+// and a comment describing it. Time layouts take precedence over durations,
+// then string formats, then base64, then enums.
+func (v *value) stringTypeStr(path valuePath, options *generateOptions) (string, string) {
+	if timeTypeStr := v.timeLayoutTypeStr(options); timeTypeStr != "" {
+		return timeTypeStr, ""
+	}
+	if durationTypeStr := v.durationTypeStr(options); durationTypeStr != "" {
+		return durationTypeStr, ""
+	}
+	if stringFormat := v.stringFormat(options); stringFormat != nil {
+		if stringFormat.TypeStr != "" {
+			return stringFormat.TypeStr, ""
+		}
+		return "string", stringFormat.Name
+	}
+	if base64TypeStr := v.base64TypeStr(options); base64TypeStr != "" {
+		return base64TypeStr, ""
+	}
+	if enumTypeStr := v.stringEnumTypeStr(path, options); enumTypeStr != "" {
+		return enumTypeStr, ""
+	}
+	return "string", ""
+}
+
+// structTypeStr returns the Go struct type of the object v, which is located at
+// path.
+func (v *value) structTypeStr(path valuePath, options *generateOptions) string {
+	// Only structs generated by unparsablePropertiesTypeStr have the methods
+	// needed to marshal their unparsable properties.
+	typeStr, _ := v.structTypeStrWithUnparsableProperties(path, UnparsablePropertiesComment, options)
+	return typeStr
+}
+
+// structTypeStrWithUnparsableProperties returns the Go struct type of the
+// object v, which is located at path, handling properties that cannot be used
+// as encoding/json struct tag names according to unparsableProperties. With
+// UnparsablePropertiesMethods, it also returns the set of these properties
+// that should be omitted when marshalled if they are zero.
+func (v *value) structTypeStrWithUnparsableProperties(path valuePath, unparsableProperties UnparsablePropertiesType, options *generateOptions) (string, map[string]bool) {
+	b := &bytes.Buffer{}
+	fmt.Fprintf(b, "struct {\n")
+	var properties, skippedProperties []string
+	omitZeroProperties := make(map[string]bool)
+	for _, property := range slices.Sorted(maps.Keys(v.objectProperties)) {
+		if isSkippedProperty(property) && unparsableProperties == UnparsablePropertiesComment {
+			skippedProperties = append(skippedProperties, property)
+		} else {
+			properties = append(properties, property)
+		}
+	}
+	var methodNames []string
+	if unparsableProperties == UnparsablePropertiesMethods {
+		methodNames = unparsablePropertiesMethodNames
+	}
+	fieldNames := options.fieldNames(path, properties, methodNames)
+	for _, property := range properties {
+		observations := v.objects
+		if propertyObjects, ok := v.propertyObjects[property]; ok {
+			// The property values of variants are shared with other variants,
+			// so the property is absent if it is not present in all objects
+			// of the variant.
+			observations = v.objectProperties[property].observations + v.objects - propertyObjects
+		}
+		goType := v.objectProperties[property].goType(path.appendProperty(property), observations, options)
+		var omitEmpty bool
+		switch options.omitEmptyTags {
+		case OmitEmptyTagsNever:
+			omitEmpty = false
+		case OmitEmptyTagsAlways:
+			omitEmpty = true
+		case OmitEmptyTagsAuto:
+			omitEmpty = goType.omitEmpty
+		}
+		var omitZero bool
+		switch options.omitZeroTags {
+		case OmitZeroTagsNever:
+			omitZero = false
+		case OmitZeroTagsAlways:
+			omitZero = true
+		case OmitZeroTagsAuto:
+			omitZero = goType.omitZero
+		}
+		if goType.omitZeroRequired {
+			omitZero = true
+		}
+
+		tags, _ := structtag.Parse("")
+		var structTagOptions []string
+		if omitEmpty {
+			structTagOptions = append(structTagOptions, "omitempty")
+		}
+		if omitZero {
+			structTagOptions = append(structTagOptions, "omitzero")
+		}
+		if goType.stringTag {
+			structTagOptions = append(structTagOptions, "string")
+		}
+		structTagName := property
+		if property == "-" && len(structTagOptions) == 0 {
+			// A struct tag name of "-" without options skips the field.
+			structTagName = "-,"
+		}
+		for _, structTagKey := range options.structTagNames {
+			tag := &structtag.Tag{
+				Key:     structTagKey,
+				Name:    structTagName,
+				Options: structTagOptions,
+			}
+			_ = tags.Set(tag)
+		}
+		tagsStr := tags.String()
+		if isUnparsableProperty(property) && unparsableProperties == UnparsablePropertiesMethods {
+			tagsStr = skippedStructTags(options.structTagNames)
+			omitZeroProperties[property] = omitEmpty || omitZero
+		}
+
+		fieldName := fieldNames[property]
+		fmt.Fprintf(b, "%s %s `%s`", fieldName.name, goType.typeStr, tagsStr)
+		var comments []string
+		if goType.comment != "" {
+			comments = append(comments, goType.comment)
+		}
+		switch {
+		case fieldName.collidesWithMethod:
+			comments = append(comments, fmt.Sprintf("renamed to avoid a collision with the %s method", fieldName.collidesWith))
+		case fieldName.renamed:
+			comments = append(comments, fmt.Sprintf("renamed to avoid a collision with %q", fieldName.collidesWith))
+		}
+		if len(comments) > 0 {
+			fmt.Fprintf(b, " // %s", strings.Join(comments, ", "))
+		}
+		fmt.Fprintf(b, "\n")
+	}
+	for _, property := range skippedProperties {
+		fmt.Fprintf(b, "// %q cannot be unmarshalled into a struct field by encoding/json.\n", property)
+	}
+	fmt.Fprintf(b, "}")
+	return b.String(), omitZeroProperties
+}
+
+// toInt64 returns a as an int64, if a is an integer that fits in an int64.
+func toInt64(a any) (int64, bool) {
+	switch a := a.(type) {
+	case int:
+		return int64(a), true
+	case int8:
+		return int64(a), true
+	case int16:
+		return int64(a), true
+	case int32:
+		return int64(a), true
+	case int64:
+		return a, true
+	case uint:
+		return int64(a), a <= math.MaxInt64
+	case uint8:
+		return int64(a), true
+	case uint16:
+		return int64(a), true
+	case uint32:
+		return int64(a), true
+	case uint64:
+		return int64(a), a <= math.MaxInt64
+	default:
+		return 0, false
+	}
+}